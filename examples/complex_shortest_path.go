@@ -80,23 +80,12 @@ func testShortestPaths(g *graph.AdjacencyList, start int) {
 	// 为每个节点重建并打印完整路径
 	for end := 0; end < 8; end++ {
 		if end != start {
-			path := reconstructPath(predecessors, start, end)
+			path, err := graph.PathTo(predecessors, start, end)
+			if err != nil {
+				path = []int{} // 无法到达的路径
+			}
 			fmt.Printf("到节点 %d 的路径: %v (距离: %.1f)\n",
 				end, path, distances[end])
 		}
 	}
 }
-
-// 重建完整路径
-func reconstructPath(predecessors map[int]int, start, end int) []int {
-	path := []int{end}
-	for current := end; current != start; {
-		prev, exists := predecessors[current]
-		if !exists {
-			return []int{} // 无法到达的路径
-		}
-		path = append([]int{prev}, path...)
-		current = prev
-	}
-	return path
-}
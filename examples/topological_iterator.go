@@ -59,42 +59,13 @@ func TopologicalIterator(g *graph.AdjacencyList) ([]int, error) {
 	return order, nil
 }
 
-// DetectCycle 使用DFS檢測圖中是否存在環
+// DetectCycle 使用DFS檢測圖中是否存在環，並輸出找到的環
 func DetectCycle(g *graph.AdjacencyList) bool {
-	visited := make(map[int]bool)
-	recStack := make(map[int]bool)
-
-	var dfs func(node int) bool
-	dfs = func(node int) bool {
-		if recStack[node] { // 當前節點已經在遞歸堆疊中，表示存在環
-			return true
-		}
-		if visited[node] { // 節點已被訪問過，無需再次檢查
-			return false
-		}
-
-		visited[node] = true
-		recStack[node] = true
-
-		// 遍歷鄰居節點
-		edges, _ := g.GetEdges(node)
-		for _, edge := range edges {
-			if dfs(edge.To) {
-				return true
-			}
-		}
-
-		recStack[node] = false
-		return false
+	cycle, found := graph.FindCycle(g)
+	if found {
+		fmt.Println("找到環:", cycle)
 	}
-
-	for _, node := range g.GetNodes() {
-		if dfs(node) {
-			return true
-		}
-	}
-
-	return false
+	return found
 }
 
 func PrintGraph(g *graph.AdjacencyList) {
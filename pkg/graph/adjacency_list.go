@@ -4,8 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"math"
-
-	"container/heap"
 )
 
 type AdjacencyList struct {
@@ -13,8 +11,23 @@ type AdjacencyList struct {
 	weighted bool           // 是否為加權圖
 	nodes    map[int]bool   // 節點列表
 	edges    map[int][]Edge // 邊列表
+	inEdges  map[int][]Edge // 有向圖的入邊列表，Edge.To 為邊的起點
+	negative int            // edges 中權重為負的項目數，供最短路徑算法以 O(1) 時間檢查
+
+	listeners    map[int]EdgeChangeListener // 邊變化的訂閱者
+	nextListener int                        // 下一個訂閱者的編號
+}
+
+// EdgeChange 描述圖中一條邊的變化。無向圖的邊只通知一次，From 與 To 為呼叫時的順序。
+type EdgeChange struct {
+	From, To  int
+	OldWeight float64 // 變化前的權重；新增的邊為 math.Inf(1)
+	NewWeight float64 // 變化後的權重；移除的邊為 math.Inf(1)
 }
 
+// EdgeChangeListener 在邊被新增、修改權重或移除後被呼叫
+type EdgeChangeListener func(change EdgeChange)
+
 // NewAdjacencyList creates a new graph using an adjacency list representation.
 //
 // Parameters:
@@ -31,10 +44,12 @@ type AdjacencyList struct {
 
 func NewAdjacencyList(directed, weighted bool) *AdjacencyList {
 	return &AdjacencyList{
-		directed: directed,
-		weighted: weighted,
-		nodes:    make(map[int]bool),
-		edges:    make(map[int][]Edge),
+		directed:  directed,
+		weighted:  weighted,
+		nodes:     make(map[int]bool),
+		edges:     make(map[int][]Edge),
+		inEdges:   make(map[int][]Edge),
+		listeners: make(map[int]EdgeChangeListener),
 	}
 }
 
@@ -69,10 +84,72 @@ func (g *AdjacencyList) AddEdge(from, to int, weight float64) error {
 	if !g.directed {
 		// 若為無向圖，添加反向邊
 		g.edges[to] = append(g.edges[to], Edge{To: from, Weight: weight}) // 若為無向圖，添加反向邊
+	} else {
+		g.inEdges[to] = append(g.inEdges[to], Edge{To: from, Weight: weight}) // 記錄入邊，供反向搜索使用
+	}
+	if weight < 0 {
+		g.negative++
+		if !g.directed {
+			g.negative++ // 反向邊
+		}
+	}
+	g.notify(EdgeChange{From: from, To: to, OldWeight: math.Inf(1), NewWeight: weight})
+	return nil
+}
+
+// UpdateEdgeWeight 修改邊 from -> to 的權重；若有多條平行邊，全部改為新的權重。
+// 無向圖會同時修改反向的邊。修改後通知所有訂閱者，通知中的 OldWeight 為原本平行邊中最小的權重。
+func (g *AdjacencyList) UpdateEdgeWeight(from, to int, weight float64) error {
+	if !g.weighted && weight != 0 {
+		return errors.New("weight not allowed in unweighted graph")
+	}
+	if !g.HasEdge(from, to) {
+		return fmt.Errorf("edge %d -> %d does not exist", from, to)
+	}
+
+	oldWeight := math.Inf(1)
+	setWeight := func(edges []Edge, to int, counted bool) {
+		for i := range edges {
+			if edges[i].To == to {
+				oldWeight = math.Min(oldWeight, edges[i].Weight)
+				if counted {
+					g.negative += negativeCount(weight) - negativeCount(edges[i].Weight)
+				}
+				edges[i].Weight = weight
+			}
+		}
+	}
+	setWeight(g.edges[from], to, true)
+	if !g.directed {
+		setWeight(g.edges[to], from, true)
+	} else {
+		setWeight(g.inEdges[to], from, false)
 	}
+	g.notify(EdgeChange{From: from, To: to, OldWeight: oldWeight, NewWeight: weight})
 	return nil
 }
 
+// Subscribe 註冊邊變化的訂閱者，返回取消訂閱的函數。
+// 訂閱者在修改圖的同一個 goroutine 中被同步呼叫，不應在回呼中修改圖。
+func (g *AdjacencyList) Subscribe(listener EdgeChangeListener) (unsubscribe func()) {
+	if g.listeners == nil {
+		g.listeners = make(map[int]EdgeChangeListener)
+	}
+	id := g.nextListener
+	g.nextListener++
+	g.listeners[id] = listener
+	return func() { delete(g.listeners, id) }
+}
+
+// notify 依訂閱順序通知所有訂閱者
+func (g *AdjacencyList) notify(change EdgeChange) {
+	for id := 0; id < g.nextListener; id++ {
+		if listener, ok := g.listeners[id]; ok {
+			listener(change)
+		}
+	}
+}
+
 func (g *AdjacencyList) GetNeighbors(node int) ([]Edge, error) {
 	// 獲取某節點的鄰居節點列表
 	// 參數:
@@ -156,18 +233,50 @@ func (g *AdjacencyList) RemoveNode(id int) error {
 	if _, exists := g.nodes[id]; !exists {
 		return fmt.Errorf("node %d does not exist", id) // 節點不存在
 	}
+
+	// 記錄被移除的邊，移除後通知訂閱者
+	removed := []EdgeChange{}
+	if len(g.listeners) > 0 {
+		for _, edge := range g.edges[id] {
+			removed = append(removed, EdgeChange{From: id, To: edge.To, OldWeight: edge.Weight, NewWeight: math.Inf(1)})
+		}
+		for _, edge := range g.inEdges[id] {
+			removed = append(removed, EdgeChange{From: edge.To, To: id, OldWeight: edge.Weight, NewWeight: math.Inf(1)})
+		}
+	}
+
+	for _, edge := range g.edges[id] {
+		g.negative -= negativeCount(edge.Weight)
+	}
 	delete(g.nodes, id) // 從節點列表中移除節點
 	delete(g.edges, id) // 從邊列表中移除節點
+	delete(g.inEdges, id)
+
+	// 移除其他節點中以該節點為起點的入邊
+	for node, in := range g.inEdges {
+		kept := in[:0]
+		for _, edge := range in {
+			if edge.To != id {
+				kept = append(kept, edge)
+			}
+		}
+		g.inEdges[node] = kept
+	}
 
 	// 遍歷所有節點，從鄰居列表中移除與該節點相關的邊
-	for node := range g.edges {
-		neighbors := g.edges[node]
-		for i := 0; i < len(neighbors); i++ {
-			if neighbors[i].To == id {
-				g.edges[node] = append(neighbors[:i], neighbors[i+1:]...) // 從鄰居列表中移除邊
-				break
+	for node, neighbors := range g.edges {
+		kept := neighbors[:0]
+		for _, edge := range neighbors {
+			if edge.To != id { // 平行邊也一併移除
+				kept = append(kept, edge)
+			} else {
+				g.negative -= negativeCount(edge.Weight)
 			}
 		}
+		g.edges[node] = kept
+	}
+	for _, change := range removed {
+		g.notify(change)
 	}
 	return nil
 }
@@ -180,66 +289,35 @@ func (g *AdjacencyList) GetNodes() []int {
 	return nodes
 }
 
-// GetEdges 返回指定節點的邊列表
-func (g *AdjacencyList) GetEdges(node int) ([]Edge, error) {
-	if _, exists := g.nodes[node]; !exists {
-		return nil, fmt.Errorf("node %d does not exist", node)
-	}
-	return g.edges[node], nil
+// hasNegativeWeights 以 O(1) 時間返回圖中是否有負權重的邊
+func (g *AdjacencyList) hasNegativeWeights() bool {
+	return g.negative > 0
 }
 
-func AStar(g *AdjacencyList, start, goal int, heuristic func(int, int) float64) ([]int, error) {
-	// 初始化距離和前驅節點
-	distances := make(map[int]float64)
-	predecessors := make(map[int]int)
-	for _, node := range g.GetNodes() {
-		distances[node] = math.Inf(1)
-		predecessors[node] = -1
+// negativeCount 權重為負時返回 1，否則返回 0
+func negativeCount(weight float64) int {
+	if weight < 0 {
+		return 1
 	}
-	distances[start] = 0
-
-	// 初始化優先隊列
-	pq := &PriorityQueue{}
-	heap.Init(pq)
-	heap.Push(pq, &Item{value: start, priority: heuristic(start, goal)})
-
-	// 開始搜索
-	for pq.Len() > 0 {
-		current := heap.Pop(pq).(*Item).value
-
-		// 如果已到達目標，停止搜索
-		if current == goal {
-			break
-		}
+	return 0
+}
 
-		// 遍歷當前節點的鄰居
-		edges, _ := g.GetEdges(current)
-		for _, edge := range edges {
-			alt := distances[current] + edge.Weight
-			if alt < distances[edge.To] {
-				// 更新距離和前驅節點
-				distances[edge.To] = alt
-				predecessors[edge.To] = current
-
-				// 計算總優先級 = 實際距離 + 啟發式距離
-				priority := alt + heuristic(edge.To, goal)
-				heap.Push(pq, &Item{value: edge.To, priority: priority})
-			}
-		}
+// GetInNeighbors 返回指向指定節點的邊列表，返回的 Edge.To 為邊的起點。
+// 無向圖中入邊與出邊相同。
+func (g *AdjacencyList) GetInNeighbors(node int) ([]Edge, error) {
+	if _, exists := g.nodes[node]; !exists {
+		return nil, fmt.Errorf("node %d does not exist in the graph", node)
 	}
-
-	// 在搜索完成後，重建路徑
-	path := []int{}
-	current := goal
-	for current != -1 {
-		path = append([]int{current}, path...)
-		current = predecessors[current]
+	if !g.directed {
+		return g.edges[node], nil
 	}
+	return g.inEdges[node], nil
+}
 
-	// 檢查是否找到路徑
-	if len(path) == 0 || path[0] != start {
-		return nil, fmt.Errorf("無法找到從節點 %d 到節點 %d 的路徑", start, goal)
+// GetEdges 返回指定節點的邊列表
+func (g *AdjacencyList) GetEdges(node int) ([]Edge, error) {
+	if _, exists := g.nodes[node]; !exists {
+		return nil, fmt.Errorf("node %d does not exist", node)
 	}
-
-	return path, nil
+	return g.edges[node], nil
 }
@@ -0,0 +1,264 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// AllPairsShortestPaths 保存所有節點對之間的最短距離與下一跳節點，
+// 由 FloydWarshall 或 Johnson 建立，可重複查詢而不需要重新計算。
+type AllPairsShortestPaths struct {
+	graph Graph
+	nodes []int       // 依 ID 遞增排序的節點
+	index map[int]int // 節點 ID 到矩陣索引的映射
+	dist  [][]float64 // dist[i][j] 為 nodes[i] 到 nodes[j] 的最短距離
+	next  [][]int     // next[i][j] 為 nodes[i] 到 nodes[j] 路徑上的下一跳索引，-1 表示無路徑
+}
+
+// FloydWarshall 以 Floyd-Warshall 算法計算所有節點對之間的最短路徑，
+// 時間複雜度 O(V^3)，適合節點數少的稠密圖。支援負權重的邊。
+//
+// Returns:
+// - The all-pairs result for Distance and Path queries.
+// - A *NegativeCycleError if the graph contains a negative cycle.
+//
+// Example:
+// apsp, _ := FloydWarshall(g)
+// d, _ := apsp.Distance(1, 4)
+// path, _ := apsp.Path(1, 4)
+func FloydWarshall(g Graph) (*AllPairsShortestPaths, error) {
+	if !g.IsWeighted() {
+		return nil, fmt.Errorf("Floyd-Warshall requires a weighted graph")
+	}
+	a := newAllPairsShortestPaths(g)
+	n := len(a.nodes)
+
+	// 以直接相連的邊初始化距離矩陣，平行邊取權重最小者
+	for i, u := range a.nodes {
+		a.dist[i][i] = 0
+		a.next[i][i] = i
+		neighbors, err := g.GetNeighbors(u)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range neighbors {
+			j := a.index[edge.To]
+			if edge.Weight < a.dist[i][j] {
+				a.dist[i][j] = edge.Weight
+				a.next[i][j] = j
+			}
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if math.IsInf(a.dist[i][k], 1) {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if alt := a.dist[i][k] + a.dist[k][j]; alt < a.dist[i][j] {
+					a.dist[i][j] = alt
+					a.next[i][j] = a.next[i][k]
+				}
+			}
+		}
+	}
+
+	// 對角線為負表示存在負權重環，以 Bellman-Ford 找出環作為證據
+	for i := 0; i < n; i++ {
+		if a.dist[i][i] < 0 {
+			if _, err := johnsonPotentials(g, a.nodes); err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("graph contains a negative cycle through node %d", a.nodes[i])
+		}
+	}
+	return a, nil
+}
+
+// Johnson 以 Johnson 算法計算所有節點對之間的最短路徑：先用 Bellman-Ford
+// 計算位能並重新加權，使所有邊權重非負，再從每個節點執行 Dijkstra。
+// 時間複雜度 O(VE log V)，適合稀疏圖。支援負權重的邊。
+//
+// Returns:
+// - The all-pairs result for Distance and Path queries.
+// - A *NegativeCycleError if the graph contains a negative cycle.
+func Johnson(g Graph) (*AllPairsShortestPaths, error) {
+	if !g.IsWeighted() {
+		return nil, fmt.Errorf("Johnson requires a weighted graph")
+	}
+	a := newAllPairsShortestPaths(g)
+
+	h, err := johnsonPotentials(g, a.nodes)
+	if err != nil {
+		return nil, err
+	}
+	reweighted := &reweightedGraph{Graph: g, potential: h}
+
+	guard := SearchOptions{}.newGuard()
+	defer guard.release()
+
+	for i, source := range a.nodes {
+		distances, predecessors, err := dijkstraSearch(reweighted, source, source, false, guard)
+		if err != nil {
+			return nil, err
+		}
+
+		a.next[i][i] = i
+		for node, d := range distances {
+			// 還原重新加權前的距離
+			a.dist[i][a.index[node]] = d - h[source] + h[node]
+		}
+
+		// 沿前驅節點找出每個節點路徑上的第一跳，已計算過的節點不再重複回溯
+		for node := range distances {
+			chain := []int{}
+			current := node
+			for a.next[i][a.index[current]] < 0 {
+				chain = append(chain, a.index[current])
+				if predecessors[current] == source {
+					a.next[i][a.index[current]] = a.index[current]
+					chain = chain[:len(chain)-1]
+					break
+				}
+				current = predecessors[current]
+			}
+			hop := a.next[i][a.index[current]]
+			for _, j := range chain {
+				a.next[i][j] = hop
+			}
+		}
+	}
+	return a, nil
+}
+
+// Nodes 返回結果中包含的所有節點（依 ID 遞增排序）
+func (a *AllPairsShortestPaths) Nodes() []int {
+	return append([]int(nil), a.nodes...)
+}
+
+// Distance 返回從 from 到 to 的最短距離，無法到達時返回 math.Inf(1)
+func (a *AllPairsShortestPaths) Distance(from, to int) (float64, error) {
+	i, j, err := a.indices(from, to)
+	if err != nil {
+		return 0, err
+	}
+	return a.dist[i][j], nil
+}
+
+// Path 返回從 from 到 to 的最短路徑，無法到達時返回包裝了 ErrNoPath 的錯誤
+func (a *AllPairsShortestPaths) Path(from, to int) (*Path, error) {
+	i, j, err := a.indices(from, to)
+	if err != nil {
+		return nil, err
+	}
+	if a.next[i][j] < 0 {
+		return nil, fmt.Errorf("%w: from %d to %d", ErrNoPath, from, to)
+	}
+
+	nodes := []int{from}
+	for i != j {
+		i = a.next[i][j]
+		nodes = append(nodes, a.nodes[i])
+	}
+	return newPath(a.graph, nodes)
+}
+
+// indices 將節點 ID 轉換為矩陣索引
+func (a *AllPairsShortestPaths) indices(from, to int) (int, int, error) {
+	i, ok := a.index[from]
+	if !ok {
+		return 0, 0, fmt.Errorf("node %d does not exist in the graph", from)
+	}
+	j, ok := a.index[to]
+	if !ok {
+		return 0, 0, fmt.Errorf("node %d does not exist in the graph", to)
+	}
+	return i, j, nil
+}
+
+// newAllPairsShortestPaths 建立距離為無窮大、沒有下一跳的空結果
+func newAllPairsShortestPaths(g Graph) *AllPairsShortestPaths {
+	nodes := g.GetNodes()
+	sort.Ints(nodes)
+
+	a := &AllPairsShortestPaths{
+		graph: g,
+		nodes: nodes,
+		index: make(map[int]int, len(nodes)),
+		dist:  make([][]float64, len(nodes)),
+		next:  make([][]int, len(nodes)),
+	}
+	for i, node := range nodes {
+		a.index[node] = i
+		a.dist[i] = make([]float64, len(nodes))
+		a.next[i] = make([]int, len(nodes))
+		for j := range nodes {
+			a.dist[i][j] = math.Inf(1)
+			a.next[i][j] = -1
+		}
+	}
+	return a
+}
+
+// johnsonPotentials 以連向所有節點、權重為 0 的虛擬起點執行 Bellman-Ford，
+// 返回每個節點的位能 h。若圖中存在負權重環則返回 *NegativeCycleError。
+func johnsonPotentials(g Graph, nodes []int) (map[int]float64, error) {
+	h := make(map[int]float64, len(nodes))
+	predecessors := make(map[int]int)
+	for _, node := range nodes {
+		h[node] = 0 // 虛擬起點到每個節點的距離初始為 0
+	}
+
+	// 加上虛擬起點共有 n+1 個節點，最多需要 n 輪鬆弛
+	for i := 0; i < len(nodes); i++ {
+		_, updated, err := relaxAllEdges(g, nodes, h, predecessors)
+		if err != nil {
+			return nil, err
+		}
+		if !updated {
+			return h, nil
+		}
+	}
+
+	last, updated, err := relaxAllEdges(g, nodes, h, predecessors)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return h, nil
+	}
+	node := last
+	for i := 0; i <= len(nodes); i++ {
+		node = predecessors[node]
+	}
+	return nil, &NegativeCycleError{Cycle: cycleThrough(predecessors, node)}
+}
+
+// reweightedGraph 以位能 h 重新加權的圖：w'(u, v) = w(u, v) + h(u) - h(v)。
+// 若 h 滿足三角不等式，所有重新加權後的權重皆非負。
+type reweightedGraph struct {
+	Graph
+	potential map[int]float64
+}
+
+func (r *reweightedGraph) GetNeighbors(node int) ([]Edge, error) {
+	neighbors, err := r.Graph.GetNeighbors(node)
+	if err != nil {
+		return nil, err
+	}
+	reweighted := make([]Edge, len(neighbors))
+	for i, edge := range neighbors {
+		w := edge.Weight + r.potential[node] - r.potential[edge.To]
+		if w < 0 {
+			w = 0 // 消除浮點誤差造成的極小負值
+		}
+		reweighted[i] = Edge{To: edge.To, Weight: w}
+	}
+	return reweighted, nil
+}
+
+func (r *reweightedGraph) GetEdges(node int) ([]Edge, error) {
+	return r.GetNeighbors(node)
+}
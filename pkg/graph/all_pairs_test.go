@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestAllPairsShortestPaths(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	g := NewAdjacencyList(true, true)
+	const n = 40
+	for i := 0; i < n; i++ {
+		g.AddNode(i*2 - 10) // 包含負數與不連續的節點 ID
+	}
+	for i := 0; i < n*4; i++ {
+		g.AddEdge(rng.Intn(n)*2-10, rng.Intn(n)*2-10, float64(rng.Intn(20)))
+	}
+
+	floyd, err := FloydWarshall(g)
+	if err != nil {
+		t.Fatalf("FloydWarshall failed: %v", err)
+	}
+	johnson, err := Johnson(g)
+	if err != nil {
+		t.Fatalf("Johnson failed: %v", err)
+	}
+
+	for _, source := range g.GetNodes() {
+		distances, _, err := Dijkstra(g, source)
+		if err != nil {
+			t.Fatalf("Dijkstra failed: %v", err)
+		}
+		for target, want := range distances {
+			for name, apsp := range map[string]*AllPairsShortestPaths{"FloydWarshall": floyd, "Johnson": johnson} {
+				got, err := apsp.Distance(source, target)
+				if err != nil {
+					t.Fatalf("%s: Distance failed: %v", name, err)
+				}
+				if got != want && math.Abs(got-want) > 1e-9 {
+					t.Fatalf("%s: distance %d -> %d = %f, want %f", name, source, target, got, want)
+				}
+				path, err := apsp.Path(source, target)
+				if math.IsInf(want, 1) {
+					if !errors.Is(err, ErrNoPath) {
+						t.Errorf("%s: expected ErrNoPath for %d -> %d, got %v", name, source, target, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("%s: Path failed: %v", name, err)
+				}
+				if math.Abs(path.Cost-want) > 1e-9 {
+					t.Errorf("%s: path cost %f does not match distance %f", name, path.Cost, want)
+				}
+			}
+		}
+	}
+}
+
+func TestAllPairsNegativeWeights(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	for i := 1; i <= 4; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(1, 2, 3)
+	g.AddEdge(2, 3, -2)
+	g.AddEdge(1, 3, 2)
+	g.AddEdge(3, 4, 1)
+
+	for name, build := range map[string]func(Graph) (*AllPairsShortestPaths, error){
+		"FloydWarshall": FloydWarshall,
+		"Johnson":       Johnson,
+	} {
+		apsp, err := build(g)
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		if d, _ := apsp.Distance(1, 4); d != 2 {
+			t.Errorf("%s: expected distance 2 from 1 to 4, got %f", name, d)
+		}
+		path, err := apsp.Path(1, 4)
+		if err != nil || len(path.Nodes) != 4 {
+			t.Errorf("%s: expected path 1 -> 2 -> 3 -> 4, got %+v, %v", name, path, err)
+		}
+	}
+
+	g.AddEdge(3, 1, -2)
+	for name, build := range map[string]func(Graph) (*AllPairsShortestPaths, error){
+		"FloydWarshall": FloydWarshall,
+		"Johnson":       Johnson,
+	} {
+		var cycleErr *NegativeCycleError
+		if _, err := build(g); !errors.As(err, &cycleErr) {
+			t.Errorf("%s: expected NegativeCycleError, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package graph
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+)
+
+// Heuristic 估計從 node 到 goal 的剩餘成本。
+// 若估計值從不超過實際成本（可採納），A* 保證找到最短路徑。
+type Heuristic func(node, goal int) float64
+
+// TieBreak 決定 A* 中 f 值相同的節點的出隊順序
+type TieBreak int
+
+const (
+	TieBreakNone     TieBreak = iota // 不指定順序
+	TieBreakHighCost                 // 優先展開 g 值較大（通常較接近目標）的節點，可減少展開數
+	TieBreakLowCost                  // 優先展開 g 值較小的節點
+)
+
+// AStarOptions 控制 AStarSearch 的行為
+type AStarOptions struct {
+	TieBreak TieBreak      // f 值相同時的出隊順序
+	Search   SearchOptions // 取消與展開節點數限制
+}
+
+// AStarResult 保存 A* 搜索的結果
+type AStarResult struct {
+	Path      *Path           // 從起點到目標的路徑
+	CostSoFar map[int]float64 // 搜索結束時每個已到達節點的 g 值（從起點出發的已知最小成本）
+	Expanded  int             // 展開的節點數
+}
+
+// AStar 使用 A* 算法計算從 start 到 goal 的最短路徑，返回路徑上的節點。
+// 需要更多資訊（路徑成本、展開數、平手規則）時請使用 AStarSearch。
+func AStar(g Graph, start, goal int, heuristic Heuristic) ([]int, error) {
+	return AStarWithOptions(g, start, goal, heuristic, SearchOptions{})
+}
+
+// AStarWithOptions 與 AStar 相同，但可透過 SearchOptions 取消搜索或限制展開的節點數。
+// 搜索被中止時，返回通往目前已展開節點中啟發值最小者的部分路徑，
+// 以及包裝了 ErrSearchCanceled 或 ErrBudgetExceeded 的錯誤。
+func AStarWithOptions(g Graph, start, goal int, heuristic Heuristic, opts SearchOptions) ([]int, error) {
+	result, err := AStarSearch(g, start, goal, heuristic, AStarOptions{Search: opts})
+	if result == nil || result.Path == nil {
+		return nil, err
+	}
+	return result.Path.Nodes, err
+}
+
+// AStarSearch performs an A* search from start to goal on any weighted graph.
+//
+// Nodes are kept in a closed set once expanded; a closed node is reopened only
+// if a cheaper path to it is found later, which keeps the search optimal even
+// for admissible but inconsistent heuristics.
+//
+// Parameters:
+// - g: The weighted graph (must implement the Graph interface).
+// - start, goal: The endpoints of the search.
+// - heuristic: An admissible estimate of the remaining cost, see EuclideanHeuristic and friends.
+// - opts: Tie-breaking, cancellation and custom edge cost (opts.Search.Weight) options.
+//
+// Returns:
+// - An AStarResult with the path, the cost-so-far of every reached node and the number of expansions.
+// - An error wrapping ErrNoPath if goal is unreachable, ErrNegativeWeight if the
+// graph has negative edges, or a search abort error together with a partial result.
+//
+// Example:
+// coords := map[int]Point{1: {0, 0}, 2: {3, 4}}
+// result, _ := AStarSearch(g, 1, 2, EuclideanHeuristic(coords), AStarOptions{TieBreak: TieBreakHighCost})
+// fmt.Println(result.Path.Nodes, result.Path.Cost, result.Expanded)
+func AStarSearch(g Graph, start, goal int, heuristic Heuristic, opts AStarOptions) (*AStarResult, error) {
+	g = opts.Search.graph(g)
+	if !g.IsWeighted() {
+		return nil, fmt.Errorf("A* requires a weighted graph")
+	}
+	if _, err := g.GetNeighbors(start); err != nil {
+		return nil, err
+	}
+	if _, err := g.GetNeighbors(goal); err != nil {
+		return nil, err
+	}
+	if err := precheckNonNegativeWeights(g); err != nil {
+		return nil, err
+	}
+
+	guard := opts.Search.newGuard()
+	defer guard.release()
+
+	costSoFar := map[int]float64{start: 0}
+	predecessors := make(map[int]int)
+	closed := make(map[int]bool)
+	result := &AStarResult{CostSoFar: costSoFar}
+
+	pq := NewPriorityQueue()
+	heap.Push(pq, opts.TieBreak.item(start, heuristic(start, goal), 0))
+
+	closest := start // 已展開節點中啟發值最小的節點，用於中止時返回部分路徑
+	for pq.Len() > 0 {
+		item := heap.Pop(pq).(*Item)
+		current := item.value
+
+		// 跳過已展開或過期的項目
+		if closed[current] || item.priority > costSoFar[current]+heuristic(current, goal) {
+			continue
+		}
+		if current == goal {
+			nodes, err := PathTo(predecessors, start, goal)
+			if err != nil {
+				return nil, err
+			}
+			if result.Path, err = newPath(g, nodes); err != nil {
+				return nil, err
+			}
+			return result, nil
+		}
+
+		if err := guard.expand(); err != nil {
+			nodes, _ := PathTo(predecessors, start, closest)
+			result.Path, _ = newPath(g, nodes)
+			return result, err // 返回部分結果
+		}
+		closed[current] = true
+		result.Expanded++
+		if heuristic(current, goal) < heuristic(closest, goal) {
+			closest = current
+		}
+
+		// 遍歷當前節點的鄰居
+		neighbors, err := g.GetNeighbors(current)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range neighbors {
+			if err := checkEdgeWeight(current, edge); err != nil {
+				return nil, err
+			}
+			alt := costSoFar[current] + edge.Weight
+			if cost, reached := costSoFar[edge.To]; reached && alt >= cost {
+				continue
+			}
+			// 找到更短的路徑，若節點已關閉則重新開啟
+			costSoFar[edge.To] = alt
+			predecessors[edge.To] = current
+			delete(closed, edge.To)
+
+			// 計算總優先級 = 實際距離 + 啟發式距離
+			heap.Push(pq, opts.TieBreak.item(edge.To, alt+heuristic(edge.To, goal), alt))
+		}
+	}
+
+	return nil, fmt.Errorf("%w: from %d to %d", ErrNoPath, start, goal)
+}
+
+// item 根據平手規則建立優先隊列項目
+func (t TieBreak) item(node int, f, cost float64) *Item {
+	item := &Item{value: node, priority: f}
+	switch t {
+	case TieBreakHighCost:
+		item.tiebreak = -cost
+	case TieBreakLowCost:
+		item.tiebreak = cost
+	}
+	return item
+}
+
+// Point 表示節點在平面上的座標
+type Point struct {
+	X, Y float64
+}
+
+// LatLng 表示節點的經緯度（以度為單位）
+type LatLng struct {
+	Lat, Lng float64
+}
+
+// EarthRadiusKm 是地球的平均半徑（公里），可作為 HaversineHeuristic 的半徑
+const EarthRadiusKm = 6371.0088
+
+// EuclideanHeuristic 返回以直線距離估計成本的啟發式函數，適用於可任意方向移動的平面圖。
+// 缺少座標的節點估計值為 0（仍然可採納）。
+func EuclideanHeuristic(coords map[int]Point) Heuristic {
+	return pointHeuristic(coords, func(dx, dy float64) float64 {
+		return math.Hypot(dx, dy)
+	})
+}
+
+// ManhattanHeuristic 返回以曼哈頓距離估計成本的啟發式函數，適用於只能上下左右移動的網格。
+func ManhattanHeuristic(coords map[int]Point) Heuristic {
+	return pointHeuristic(coords, func(dx, dy float64) float64 {
+		return dx + dy
+	})
+}
+
+// OctileHeuristic 返回以八方向距離估計成本的啟發式函數，適用於可斜向移動（成本為 √2）的網格。
+func OctileHeuristic(coords map[int]Point) Heuristic {
+	return pointHeuristic(coords, func(dx, dy float64) float64 {
+		return math.Max(dx, dy) + (math.Sqrt2-1)*math.Min(dx, dy)
+	})
+}
+
+// HaversineHeuristic 返回以大圓距離估計成本的啟發式函數，適用於以經緯度表示的道路圖。
+// radius 為球體半徑，結果的單位與 radius 相同（例如 EarthRadiusKm 表示公里）。
+func HaversineHeuristic(coords map[int]LatLng, radius float64) Heuristic {
+	return func(node, goal int) float64 {
+		a, ok := coords[node]
+		b, ok2 := coords[goal]
+		if !ok || !ok2 {
+			return 0
+		}
+		lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+		dLat := lat2 - lat1
+		dLng := (b.Lng - a.Lng) * math.Pi / 180
+		h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+		return 2 * radius * math.Asin(math.Min(1, math.Sqrt(h)))
+	}
+}
+
+// pointHeuristic 以座標差的絕對值計算啟發式函數
+func pointHeuristic(coords map[int]Point, distance func(dx, dy float64) float64) Heuristic {
+	return func(node, goal int) float64 {
+		a, ok := coords[node]
+		b, ok2 := coords[goal]
+		if !ok || !ok2 {
+			return 0
+		}
+		return distance(math.Abs(a.X-b.X), math.Abs(a.Y-b.Y))
+	}
+}
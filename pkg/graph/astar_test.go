@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// buildGrid 建立 width x height 的四方向網格圖，節點 ID 為 y*width+x
+func buildGrid(width, height int, blocked map[int]bool) (*AdjacencyList, map[int]Point) {
+	g := NewAdjacencyList(false, true)
+	coords := make(map[int]Point)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			id := y*width + x
+			g.AddNode(id)
+			coords[id] = Point{X: float64(x), Y: float64(y)}
+		}
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			id := y*width + x
+			if blocked[id] {
+				continue
+			}
+			if x+1 < width && !blocked[id+1] {
+				g.AddEdge(id, id+1, 1)
+			}
+			if y+1 < height && !blocked[id+width] {
+				g.AddEdge(id, id+width, 1)
+			}
+		}
+	}
+	return g, coords
+}
+
+func TestAStarSearch(t *testing.T) {
+	// 中間有一道牆的 10x10 網格
+	blocked := map[int]bool{}
+	for y := 0; y < 9; y++ {
+		blocked[y*10+5] = true
+	}
+	g, coords := buildGrid(10, 10, blocked)
+
+	expected, err := ShortestPath(g, 0, 9)
+	if err != nil {
+		t.Fatalf("ShortestPath failed: %v", err)
+	}
+
+	var expandedNone int
+	for _, tieBreak := range []TieBreak{TieBreakNone, TieBreakHighCost, TieBreakLowCost} {
+		for name, heuristic := range map[string]Heuristic{
+			"Euclidean": EuclideanHeuristic(coords),
+			"Manhattan": ManhattanHeuristic(coords),
+			"Octile":    OctileHeuristic(coords),
+		} {
+			result, err := AStarSearch(g, 0, 9, heuristic, AStarOptions{TieBreak: tieBreak})
+			if err != nil {
+				t.Fatalf("%s: AStarSearch failed: %v", name, err)
+			}
+			if result.Path.Cost != expected.Cost {
+				t.Errorf("%s: expected cost %f, got %f", name, expected.Cost, result.Path.Cost)
+			}
+			if result.CostSoFar[9] != expected.Cost {
+				t.Errorf("%s: expected cost-so-far %f at goal, got %f", name, expected.Cost, result.CostSoFar[9])
+			}
+			if name == "Manhattan" && tieBreak == TieBreakNone {
+				expandedNone = result.Expanded
+			}
+			if name == "Manhattan" && tieBreak == TieBreakHighCost && result.Expanded > expandedNone {
+				t.Errorf("Expected high-cost tie-breaking not to expand more nodes: %d > %d", result.Expanded, expandedNone)
+			}
+		}
+	}
+
+	// -1 是合法的節點 ID
+	g2 := NewAdjacencyList(true, true)
+	g2.AddNode(-1)
+	g2.AddNode(-2)
+	g2.AddEdge(-1, -2, 3)
+	path, err := AStar(g2, -1, -2, func(int, int) float64 { return 0 })
+	if err != nil || len(path) != 2 || path[0] != -1 || path[1] != -2 {
+		t.Errorf("Expected path [-1 -2], got %v, %v", path, err)
+	}
+	if _, err := AStar(g2, -2, -1, func(int, int) float64 { return 0 }); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath, got %v", err)
+	}
+}
+
+func TestHaversineHeuristic(t *testing.T) {
+	coords := map[int]LatLng{
+		1: {Lat: 25.0330, Lng: 121.5654}, // 台北
+		2: {Lat: 22.6273, Lng: 120.3014}, // 高雄
+	}
+	d := HaversineHeuristic(coords, EarthRadiusKm)(1, 2)
+	if math.Abs(d-297) > 5 {
+		t.Errorf("Expected about 297 km between Taipei and Kaohsiung, got %f", d)
+	}
+	if HaversineHeuristic(coords, EarthRadiusKm)(1, 3) != 0 {
+		t.Errorf("Expected 0 for nodes without coordinates")
+	}
+}
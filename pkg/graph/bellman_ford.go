@@ -0,0 +1,246 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNegativeWeight 表示圖中含有負權重的邊，而演算法（例如 Dijkstra、A*）不支援負權重
+var ErrNegativeWeight = errors.New("negative edge weight")
+
+// NegativeCycleError 表示圖中存在可從起點到達的負權重環
+type NegativeCycleError struct {
+	Cycle []int // 環上的節點，首尾為同一個節點，依邊的方向排列
+}
+
+func (e *NegativeCycleError) Error() string {
+	return fmt.Sprintf("graph contains a negative cycle: %v", e.Cycle)
+}
+
+// BellmanFord 實現 Bellman-Ford 最短路徑算法，支援負權重的邊。
+//
+// Parameters:
+// - g: The weighted graph (must implement the Graph interface).
+// - start: The source node.
+//
+// Returns:
+// - The distance to every node (math.Inf(1) for unreachable nodes) and the predecessor map.
+// - A *NegativeCycleError containing the cycle if a negative cycle is reachable from start.
+// Note that in an undirected graph any negative edge forms a negative cycle.
+//
+// Example:
+// distances, predecessors, err := BellmanFord(g, 0)
+// var cycleErr *NegativeCycleError
+//
+//	if errors.As(err, &cycleErr) {
+//		fmt.Println("arbitrage:", cycleErr.Cycle)
+//	}
+func BellmanFord(g Graph, start int) (distances map[int]float64, predecessors map[int]int, err error) {
+	if !g.IsWeighted() {
+		return nil, nil, fmt.Errorf("Bellman-Ford requires a weighted graph")
+	}
+	if _, err := g.GetNeighbors(start); err != nil {
+		return nil, nil, err
+	}
+
+	nodes := g.GetNodes()
+	distances = make(map[int]float64, len(nodes))
+	predecessors = make(map[int]int)
+	for _, node := range nodes {
+		distances[node] = math.Inf(1)
+	}
+	distances[start] = 0
+
+	// 最多進行 n-1 輪鬆弛，若某一輪沒有更新則提前結束
+	for i := 0; i < len(nodes)-1; i++ {
+		_, updated, err := relaxAllEdges(g, nodes, distances, predecessors)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !updated {
+			return distances, predecessors, nil
+		}
+	}
+
+	// 第 n 輪仍能鬆弛，表示存在負權重環
+	last, updated, err := relaxAllEdges(g, nodes, distances, predecessors)
+	if err != nil {
+		return nil, nil, err
+	}
+	if updated {
+		// 沿前驅節點回溯 n 步，必定會落在環上
+		node := last
+		for i := 0; i < len(nodes); i++ {
+			node = predecessors[node]
+		}
+		return distances, predecessors, &NegativeCycleError{Cycle: cycleThrough(predecessors, node)}
+	}
+	return distances, predecessors, nil
+}
+
+// relaxAllEdges 對所有從已到達節點出發的邊進行一次鬆弛，
+// 返回最後一個被更新的節點以及是否有任何更新
+func relaxAllEdges(g Graph, nodes []int, distances map[int]float64, predecessors map[int]int) (last int, updated bool, err error) {
+	for _, u := range nodes {
+		if math.IsInf(distances[u], 1) {
+			continue
+		}
+		neighbors, err := g.GetNeighbors(u)
+		if err != nil {
+			return 0, false, err
+		}
+		for _, edge := range neighbors {
+			if alt := distances[u] + edge.Weight; alt < distances[edge.To] {
+				distances[edge.To] = alt
+				predecessors[edge.To] = u
+				last, updated = edge.To, true
+			}
+		}
+	}
+	return last, updated, nil
+}
+
+// SPFA 實現 Shortest Path Faster Algorithm（以佇列最佳化的 Bellman-Ford），支援負權重的邊。
+// 回傳值與 BellmanFord 相同；在稀疏圖上通常快得多，但最壞情況的複雜度相同。
+func SPFA(g Graph, start int) (distances map[int]float64, predecessors map[int]int, err error) {
+	if !g.IsWeighted() {
+		return nil, nil, fmt.Errorf("SPFA requires a weighted graph")
+	}
+	if _, err := g.GetNeighbors(start); err != nil {
+		return nil, nil, err
+	}
+
+	nodes := g.GetNodes()
+	distances = make(map[int]float64, len(nodes))
+	predecessors = make(map[int]int)
+	for _, node := range nodes {
+		distances[node] = math.Inf(1)
+	}
+	distances[start] = 0
+
+	queue := []int{start}
+	inQueue := map[int]bool{start: true}
+	relaxCount := make(map[int]int) // 每個節點被鬆弛的次數
+
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		inQueue[u] = false
+
+		neighbors, err := g.GetNeighbors(u)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, edge := range neighbors {
+			v := edge.To
+			alt := distances[u] + edge.Weight
+			if alt >= distances[v] {
+				continue
+			}
+			distances[v] = alt
+			predecessors[v] = u
+
+			// 節點被鬆弛 n 次以上，表示存在負權重環
+			relaxCount[v]++
+			if relaxCount[v] >= len(nodes) {
+				if cycle := findPredecessorCycle(predecessors); cycle != nil {
+					return distances, predecessors, &NegativeCycleError{Cycle: cycle}
+				}
+				// 前驅節點圖中尚未形成環，改用 Bellman-Ford 找出環
+				return BellmanFord(g, start)
+			}
+			if !inQueue[v] {
+				queue = append(queue, v)
+				inQueue[v] = true
+			}
+		}
+	}
+	return distances, predecessors, nil
+}
+
+// cycleThrough 返回前驅節點圖中經過 node 的環，依邊的方向排列且首尾相同
+func cycleThrough(predecessors map[int]int, node int) []int {
+	cycle := []int{node}
+	for current := predecessors[node]; current != node; current = predecessors[current] {
+		cycle = append(cycle, current)
+	}
+	cycle = append(cycle, node)
+
+	// 前驅節點是反向的，反轉為邊的方向
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
+	}
+	return cycle
+}
+
+// findPredecessorCycle 在前驅節點圖中尋找環，找不到時返回 nil。
+// 前驅節點圖中的任何環都是負權重環。
+func findPredecessorCycle(predecessors map[int]int) []int {
+	state := make(map[int]int) // 0: 未訪問, 1: 目前鏈上, 2: 已確認不在環上
+	for start := range predecessors {
+		if state[start] != 0 {
+			continue
+		}
+		chain := []int{}
+		node := start
+		for {
+			if state[node] == 1 {
+				return cycleThrough(predecessors, node)
+			}
+			if state[node] == 2 {
+				break
+			}
+			state[node] = 1
+			chain = append(chain, node)
+			pred, ok := predecessors[node]
+			if !ok {
+				break
+			}
+			node = pred
+		}
+		for _, n := range chain {
+			state[n] = 2
+		}
+	}
+	return nil
+}
+
+// checkNonNegativeWeights 掃描整張圖確認沒有負權重的邊，只用於本來就會讀取所有邊的預處理。
+// 點對點或可提前結束的搜索應改用 precheckNonNegativeWeights 與 checkEdgeWeight。
+func checkNonNegativeWeights(g Graph) error {
+	for _, from := range g.GetNodes() {
+		neighbors, err := g.GetNeighbors(from)
+		if err != nil {
+			return err
+		}
+		for _, edge := range neighbors {
+			if edge.Weight < 0 {
+				return fmt.Errorf("%w: edge %d -> %d has weight %v", ErrNegativeWeight, from, edge.To, edge.Weight)
+			}
+		}
+	}
+	return nil
+}
+
+// negativeWeightTracker 由能以 O(1) 時間判斷是否含有負權重邊的圖實作，例如 AdjacencyList
+type negativeWeightTracker interface {
+	hasNegativeWeights() bool
+}
+
+// precheckNonNegativeWeights 在搜索開始前以 O(1) 時間檢查負權重，不掃描整張圖。
+// 無法快速判斷的圖（例如 WithWeights 視圖）返回 nil，由搜索以 checkEdgeWeight 檢查實際展開的邊。
+func precheckNonNegativeWeights(g Graph) error {
+	if t, ok := g.(negativeWeightTracker); ok && t.hasNegativeWeights() {
+		return fmt.Errorf("%w: graph contains edges with negative weight", ErrNegativeWeight)
+	}
+	return nil
+}
+
+// checkEdgeWeight 在搜索展開節點時檢查經過的邊是否為負權重
+func checkEdgeWeight(from int, edge Edge) error {
+	if edge.Weight < 0 {
+		return fmt.Errorf("%w: edge %d -> %d has weight %v", ErrNegativeWeight, from, edge.To, edge.Weight)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestBellmanFordNegativeEdges(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	for _, node := range []int{-1, 0, 1, 2, 3} {
+		g.AddNode(node)
+	}
+	g.AddEdge(-1, 0, 4)
+	g.AddEdge(-1, 1, 5)
+	g.AddEdge(1, 0, -3)
+	g.AddEdge(0, 2, 2)
+	g.AddEdge(2, 3, -1)
+
+	expected := map[int]float64{-1: 0, 0: 2, 1: 5, 2: 4, 3: 3}
+	for name, algorithm := range map[string]func(Graph, int) (map[int]float64, map[int]int, error){
+		"BellmanFord": BellmanFord,
+		"SPFA":        SPFA,
+	} {
+		distances, predecessors, err := algorithm(g, -1)
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		for node, want := range expected {
+			if math.Abs(distances[node]-want) > 1e-10 {
+				t.Errorf("%s: incorrect distance for node %d: got %f, want %f", name, node, distances[node], want)
+			}
+		}
+		if predecessors[0] != 1 {
+			t.Errorf("%s: expected predecessor of node 0 to be 1, got %d", name, predecessors[0])
+		}
+	}
+
+	// Dijkstra 應拒絕負權重
+	if _, _, err := Dijkstra(g, -1); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Expected ErrNegativeWeight from Dijkstra, got %v", err)
+	}
+}
+
+func TestBellmanFordNegativeCycle(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	for i := 0; i < 5; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, -2)
+	g.AddEdge(3, 1, -1)
+	g.AddEdge(3, 4, 1)
+
+	for name, algorithm := range map[string]func(Graph, int) (map[int]float64, map[int]int, error){
+		"BellmanFord": BellmanFord,
+		"SPFA":        SPFA,
+	} {
+		_, _, err := algorithm(g, 0)
+		var cycleErr *NegativeCycleError
+		if !errors.As(err, &cycleErr) {
+			t.Fatalf("%s: expected NegativeCycleError, got %v", name, err)
+		}
+
+		// 驗證返回的環確實存在且總權重為負
+		cycle := cycleErr.Cycle
+		if len(cycle) != 4 || cycle[0] != cycle[len(cycle)-1] {
+			t.Fatalf("%s: unexpected cycle %v", name, cycle)
+		}
+		path, err := newPath(g, cycle)
+		if err != nil {
+			t.Fatalf("%s: cycle %v is not a valid walk: %v", name, cycle, err)
+		}
+		if path.Cost >= 0 {
+			t.Errorf("%s: expected negative cycle cost, got %f", name, path.Cost)
+		}
+	}
+}
+
+// countingGraph 記錄 GetNeighbors 被呼叫的次數，且不提供 O(1) 的負權重檢查
+type countingGraph struct {
+	Graph
+	calls int
+}
+
+func (c *countingGraph) GetNeighbors(node int) ([]Edge, error) {
+	c.calls++
+	return c.Graph.GetNeighbors(node)
+}
+
+func TestNegativeWeightChecks(t *testing.T) {
+	// AdjacencyList 在修改圖時維護負權重邊的數量
+	g := NewAdjacencyList(false, true)
+	for i := 0; i < 4; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, -1)
+	g.AddEdge(2, 2, -2)
+	if _, err := ShortestPath(g, 0, 1); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Expected ErrNegativeWeight, got %v", err)
+	}
+	g.UpdateEdgeWeight(2, 1, 3)
+	if _, err := ShortestPath(g, 0, 1); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Expected ErrNegativeWeight for the self-loop, got %v", err)
+	}
+	g.RemoveNode(2)
+	if _, err := ShortestPath(g, 0, 1); err != nil {
+		t.Errorf("Unexpected error after removing negative edges: %v", err)
+	}
+	g.AddEdge(1, 3, -1)
+	g.UpdateEdgeWeight(3, 1, 0)
+	if _, _, err := Dijkstra(g, 0); err != nil {
+		t.Errorf("Unexpected error after updating the weight: %v", err)
+	}
+
+	// 其他圖只檢查展開的邊：遠處的負權重邊不影響提前結束的搜索
+	line := NewAdjacencyList(true, true)
+	const n = 100
+	for i := 0; i < n; i++ {
+		line.AddNode(i)
+	}
+	for i := 0; i+1 < n; i++ {
+		line.AddEdge(i, i+1, 1)
+	}
+	line.UpdateEdgeWeight(n-2, n-1, -1)
+	counting := &countingGraph{Graph: line}
+	if _, err := ShortestPath(counting, 0, 3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, _, err := NodesWithinRadius(counting, 0, 2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if counting.calls > 20 {
+		t.Errorf("Expected the searches to read only nearby edges, got %d GetNeighbors calls", counting.calls)
+	}
+	if _, _, err := Dijkstra(counting, 0); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Expected ErrNegativeWeight once the edge is reached, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package graph
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+)
+
+// BidirectionalDijkstra 同時從起點（沿出邊）與終點（沿入邊）執行 Dijkstra，
+// 在兩側相遇後即可停止，對點對點查詢通常比單向搜索展開更少的節點。
+// 有向圖的反向搜索使用 InNeighborGraph，若圖未實作則先建立反向鄰接表。
+//
+// 停止條件：設 mu 為目前找到的最短路徑長度，當兩側優先隊列的最小鍵值之和
+// 不小於 mu 時，不可能再找到更短的路徑。
+//
+// Returns:
+// - The same Path as ShortestPath(g, start, target).
+// - An error wrapping ErrNoPath if target is unreachable, or ErrNegativeWeight for negative edges.
+//
+// Example:
+// path, _ := BidirectionalDijkstra(g, 1, 42)
+// fmt.Println(path.Nodes, path.Cost)
+func BidirectionalDijkstra(g Graph, start, target int) (*Path, error) {
+	if !g.IsWeighted() {
+		return nil, fmt.Errorf("Dijkstra requires a weighted graph")
+	}
+	if _, err := g.GetNeighbors(start); err != nil {
+		return nil, err
+	}
+	if _, err := g.GetNeighbors(target); err != nil {
+		return nil, err
+	}
+	if err := precheckNonNegativeWeights(g); err != nil {
+		return nil, err
+	}
+	if start == target {
+		return newPath(g, []int{start})
+	}
+	inNeighbors, err := inNeighborFunc(g)
+	if err != nil {
+		return nil, err
+	}
+
+	forward := newDijkstraSide(start, g.GetNeighbors, false)
+	backward := newDijkstraSide(target, inNeighbors, true)
+	best := math.Inf(1)
+	meet, met := 0, false
+
+	for {
+		fTop, fOk := forward.peek()
+		bTop, bOk := backward.peek()
+		if !fOk || !bOk || fTop+bTop >= best {
+			break
+		}
+
+		// 展開鍵值較小的一側
+		side, other := forward, backward
+		if bTop < fTop {
+			side, other = backward, forward
+		}
+		node, length, found, err := side.settle(other)
+		if err != nil {
+			return nil, err
+		}
+		if found && length < best {
+			best, meet, met = length, node, true
+		}
+	}
+
+	if !met {
+		return nil, fmt.Errorf("%w: from %d to %d", ErrNoPath, start, target)
+	}
+
+	// 組合路徑：start ... meet ... target
+	nodes, err := PathTo(forward.predecessors, start, meet)
+	if err != nil {
+		return nil, err
+	}
+	for node := meet; node != target; {
+		node = backward.predecessors[node] // 反向搜索中的前驅即原圖中的下一跳
+		nodes = append(nodes, node)
+	}
+	return newPath(g, nodes)
+}
+
+// dijkstraSide 是雙向 Dijkstra 其中一側的搜索狀態
+type dijkstraSide struct {
+	neighbors    func(node int) ([]Edge, error) // 此側展開節點的方式（出邊或入邊）
+	reverse      bool                           // 是否沿入邊展開，用於以原圖方向回報負權重的邊
+	distances    map[int]float64
+	predecessors map[int]int
+	settled      map[int]bool
+	pq           *PriorityQueue
+}
+
+func newDijkstraSide(root int, neighbors func(node int) ([]Edge, error), reverse bool) *dijkstraSide {
+	s := &dijkstraSide{
+		neighbors:    neighbors,
+		reverse:      reverse,
+		distances:    map[int]float64{root: 0},
+		predecessors: make(map[int]int),
+		settled:      make(map[int]bool),
+		pq:           NewPriorityQueue(),
+	}
+	heap.Push(s.pq, &Item{value: root, priority: 0})
+	return s
+}
+
+// peek 移除過期的項目並返回隊列中的最小鍵值
+func (s *dijkstraSide) peek() (float64, bool) {
+	for s.pq.Len() > 0 {
+		top := (*s.pq)[0]
+		if !s.settled[top.value] && top.priority <= s.distances[top.value] {
+			return top.priority, true
+		}
+		heap.Pop(s.pq)
+	}
+	return 0, false
+}
+
+// settle 確定隊列中最近節點的距離並鬆弛其鄰居，
+// 若鬆弛過程中與另一側相遇，返回經過的節點與路徑總長度中最短者
+func (s *dijkstraSide) settle(other *dijkstraSide) (meet int, length float64, found bool, err error) {
+	u := heap.Pop(s.pq).(*Item).value
+	s.settled[u] = true
+
+	length = math.Inf(1)
+	if d, ok := other.distances[u]; ok {
+		meet, length, found = u, s.distances[u]+d, true
+	}
+
+	neighbors, err := s.neighbors(u)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	for _, edge := range neighbors {
+		v := edge.To
+		if err := s.checkWeight(u, edge); err != nil {
+			return 0, 0, false, err
+		}
+		if s.settled[v] {
+			continue
+		}
+		alt := s.distances[u] + edge.Weight
+		if d, ok := s.distances[v]; !ok || alt < d {
+			s.distances[v] = alt
+			s.predecessors[v] = u
+			heap.Push(s.pq, &Item{value: v, priority: alt})
+		}
+		if d, ok := other.distances[v]; ok && s.distances[v]+d < length {
+			meet, length, found = v, s.distances[v]+d, true
+		}
+	}
+	return meet, length, found, nil
+}
+
+// checkWeight 檢查此側經過的邊是否為負權重，反向搜索時以原圖中的方向回報
+func (s *dijkstraSide) checkWeight(u int, edge Edge) error {
+	if s.reverse {
+		return checkEdgeWeight(edge.To, Edge{To: u, Weight: edge.Weight})
+	}
+	return checkEdgeWeight(u, edge)
+}
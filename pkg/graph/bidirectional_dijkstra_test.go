@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBidirectionalDijkstra(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	for _, directed := range []bool{true, false} {
+		g := NewAdjacencyList(directed, true)
+		const n = 200
+		for i := 0; i < n; i++ {
+			g.AddNode(i)
+		}
+		for i := 0; i < n*3; i++ {
+			g.AddEdge(rng.Intn(n), rng.Intn(n), float64(rng.Intn(50)))
+		}
+
+		for trial := 0; trial < 100; trial++ {
+			start, target := rng.Intn(n), rng.Intn(n)
+			expected, expectedErr := ShortestPath(g, start, target)
+			for _, graph := range []Graph{g, plainGraph{g}} {
+				path, err := BidirectionalDijkstra(graph, start, target)
+				if expectedErr != nil {
+					if !errors.Is(err, ErrNoPath) {
+						t.Errorf("Expected ErrNoPath from %d to %d, got %v", start, target, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("BidirectionalDijkstra failed: %v", err)
+				}
+				if math.Abs(path.Cost-expected.Cost) > 1e-9 {
+					t.Fatalf("Cost from %d to %d: got %f, want %f", start, target, path.Cost, expected.Cost)
+				}
+				if path.Nodes[0] != start || path.Nodes[len(path.Nodes)-1] != target {
+					t.Fatalf("Unexpected path endpoints: %v", path.Nodes)
+				}
+			}
+		}
+	}
+}
+
+func TestBidirectionalDijkstraNegativeWeight(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	for i := 0; i < 4; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, -1)
+	if _, err := BidirectionalDijkstra(g, 0, 3); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Expected ErrNegativeWeight, got %v", err)
+	}
+	// 反向搜索遇到負權重的邊時，錯誤訊息仍以原圖的方向描述該邊
+	_, err := BidirectionalDijkstra(plainGraph{g}, 0, 3)
+	if !errors.Is(err, ErrNegativeWeight) {
+		t.Fatalf("Expected ErrNegativeWeight from the search, got %v", err)
+	}
+	if want := "negative edge weight: edge 2 -> 3 has weight -1"; err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+}
+
+func TestBidirectionalDijkstraChecksOnlyExpandedEdges(t *testing.T) {
+	// 遠處的負權重邊不影響查詢，也不應讓搜索讀取整張圖
+	line := NewAdjacencyList(false, true)
+	const n = 100
+	for i := 0; i < n; i++ {
+		line.AddNode(i)
+	}
+	for i := 0; i+1 < n; i++ {
+		line.AddEdge(i, i+1, 1)
+	}
+	negativeEnd := WithWeights(line, func(from, to int, e Edge) float64 {
+		if from >= n-2 && to >= n-2 {
+			return -1
+		}
+		return e.Weight
+	})
+	counting := &countingGraph{Graph: negativeEnd}
+	path, err := BidirectionalDijkstra(counting, 0, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if path.Cost != 3 {
+		t.Errorf("Expected cost 3, got %v", path.Cost)
+	}
+	if counting.calls > 20 {
+		t.Errorf("Expected the search to read only nearby edges, got %d GetNeighbors calls", counting.calls)
+	}
+	if _, err := BidirectionalDijkstra(counting, 0, n-1); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Expected ErrNegativeWeight once the edge is reached, got %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package graph
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+)
+
+// WidestPaths 計算從 start 到每個節點的最寬路徑（maximum-bottleneck path）：
+// 路徑的寬度是路徑上最小的邊權重，例如頻寬或載重上限，目標是使其最大。
+// 算法與 Dijkstra 相同，只是以 min 取代加法並優先展開寬度最大的節點，因此允許負權重。
+//
+// Returns:
+// - widths: 起點為 math.Inf(1)，無法到達的節點為 math.Inf(-1)。
+// - predecessors: 與 Dijkstra 相同的前驅節點表，可以用 PathTo 重建路徑。
+//
+// Example:
+// widths, predecessors, _ := WidestPaths(g, 0)
+// nodes, _ := PathTo(predecessors, 0, 5)
+// fmt.Println(nodes, widths[5])
+func WidestPaths(g Graph, start int) (widths map[int]float64, predecessors map[int]int, err error) {
+	return bottleneckPaths(g, start, widest)
+}
+
+// MinimaxPaths 計算從 start 到每個節點的 minimax 路徑：
+// 使路徑上最大的邊權重（例如單段路線的最高風險）盡可能小。
+//
+// Returns:
+// - values: 每個節點的最小可能最大邊權重；起點為 math.Inf(-1)，無法到達的節點為 math.Inf(1)。
+// - predecessors: 與 Dijkstra 相同的前驅節點表。
+func MinimaxPaths(g Graph, start int) (values map[int]float64, predecessors map[int]int, err error) {
+	return bottleneckPaths(g, start, minimax)
+}
+
+// WidestPath 返回從 start 到 target 的最寬路徑與其寬度（路徑上最小的邊權重），
+// 在 target 確定後立即停止。平行邊取權重最大者，Path.Cost 仍為邊權重的總和。
+// 若 start 等於 target，寬度為 math.Inf(1)。
+func WidestPath(g Graph, start, target int) (*Path, float64, error) {
+	return bottleneckPath(g, start, target, widest)
+}
+
+// MinimaxPath 返回從 start 到 target 使最大邊權重最小的路徑與該最大邊權重。
+// 平行邊取權重最小者，Path.Cost 仍為邊權重的總和。若 start 等於 target，結果為 math.Inf(-1)。
+func MinimaxPath(g Graph, start, target int) (*Path, float64, error) {
+	return bottleneckPath(g, start, target, minimax)
+}
+
+// bottleneckObjective 描述瓶頸路徑的目標：如何沿邊組合數值，以及何者較好
+type bottleneckObjective struct {
+	start     float64                             // 起點的數值（組合運算的單位元）
+	unreached float64                             // 無法到達的節點的數值
+	combine   func(value, weight float64) float64 // 沿邊延伸路徑後的數值
+	better    func(a, b float64) bool             // a 是否比 b 好
+	maximize  bool                                // 數值越大越好
+}
+
+var (
+	widest = bottleneckObjective{
+		start:     math.Inf(1),
+		unreached: math.Inf(-1),
+		combine:   math.Min,
+		better:    func(a, b float64) bool { return a > b },
+		maximize:  true,
+	}
+	minimax = bottleneckObjective{
+		start:     math.Inf(-1),
+		unreached: math.Inf(1),
+		combine:   math.Max,
+		better:    func(a, b float64) bool { return a < b },
+	}
+)
+
+// priority 將數值轉換為優先隊列中的優先級（越好越小）
+func (o bottleneckObjective) priority(value float64) float64 {
+	if o.maximize {
+		return -value
+	}
+	return value
+}
+
+// bottleneckPaths 計算從 start 到所有節點的瓶頸值，無法到達的節點填入 unreached
+func bottleneckPaths(g Graph, start int, objective bottleneckObjective) (map[int]float64, map[int]int, error) {
+	if !g.IsWeighted() {
+		return nil, nil, fmt.Errorf("bottleneck paths require a weighted graph")
+	}
+	values, predecessors, err := bottleneckSearch(g, start, start, false, objective)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, node := range g.GetNodes() {
+		if _, ok := values[node]; !ok {
+			values[node] = objective.unreached
+		}
+	}
+	return values, predecessors, nil
+}
+
+// bottleneckPath 計算從 start 到 target 的瓶頸路徑
+func bottleneckPath(g Graph, start, target int, objective bottleneckObjective) (*Path, float64, error) {
+	if !g.IsWeighted() {
+		return nil, 0, fmt.Errorf("bottleneck paths require a weighted graph")
+	}
+	if _, err := g.GetNeighbors(target); err != nil {
+		return nil, 0, err
+	}
+	values, predecessors, err := bottleneckSearch(g, start, target, true, objective)
+	if err != nil {
+		return nil, 0, err
+	}
+	nodes, err := PathTo(predecessors, start, target)
+	if err != nil {
+		return nil, 0, err
+	}
+	path, err := newPathBy(g, nodes, func(a, b Edge) bool { return objective.better(a.Weight, b.Weight) })
+	if err != nil {
+		return nil, 0, err
+	}
+	return path, values[target], nil
+}
+
+// bottleneckSearch 是瓶頸路徑算法的核心，結構與 dijkstraSearch 相同。
+// 數值表只包含已到達的節點；若 hasTarget 為 true，則在 target 確定後立即停止。
+func bottleneckSearch(g Graph, start, target int, hasTarget bool, objective bottleneckObjective) (map[int]float64, map[int]int, error) {
+	if _, err := g.GetNeighbors(start); err != nil {
+		return nil, nil, err
+	}
+
+	values := map[int]float64{start: objective.start}
+	predecessors := make(map[int]int)
+	visited := make(map[int]bool)
+	pq := NewPriorityQueue()
+	heap.Push(pq, &Item{value: start, priority: objective.priority(objective.start)})
+
+	for pq.Len() > 0 {
+		u := heap.Pop(pq).(*Item).value
+		if visited[u] {
+			continue
+		}
+		visited[u] = true
+		if hasTarget && u == target {
+			break
+		}
+
+		neighbors, err := g.GetNeighbors(u)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, edge := range neighbors {
+			v := edge.To
+			if visited[v] {
+				continue
+			}
+			candidate := objective.combine(values[u], edge.Weight)
+			if current, ok := values[v]; !ok || objective.better(candidate, current) {
+				values[v] = candidate
+				predecessors[v] = u
+				heap.Push(pq, &Item{value: v, priority: objective.priority(candidate)})
+			}
+		}
+	}
+	return values, predecessors, nil
+}
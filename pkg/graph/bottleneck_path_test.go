@@ -0,0 +1,120 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestWidestPath(t *testing.T) {
+	// 0 -> 1 -> 3 的寬度為 5；0 -> 2 -> 3 的寬度為 3，但總權重較小
+	g := NewAdjacencyList(true, true)
+	for i := 0; i <= 4; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 10)
+	g.AddEdge(1, 3, 5)
+	g.AddEdge(0, 2, 3)
+	g.AddEdge(2, 3, 3)
+	g.AddEdge(1, 3, 1) // 較窄的平行邊
+
+	path, width, err := WidestPath(g, 0, 3)
+	if err != nil {
+		t.Fatalf("WidestPath failed: %v", err)
+	}
+	if width != 5 || !reflect.DeepEqual(path.Nodes, []int{0, 1, 3}) {
+		t.Errorf("Expected path [0 1 3] with width 5, got %v with width %v", path.Nodes, width)
+	}
+	if path.Edges[1].Weight != 5 || path.Cost != 15 {
+		t.Errorf("Expected the wider parallel edge to be used, got %+v", path.Edges)
+	}
+
+	widths, predecessors, err := WidestPaths(g, 0)
+	if err != nil {
+		t.Fatalf("WidestPaths failed: %v", err)
+	}
+	want := map[int]float64{0: math.Inf(1), 1: 10, 2: 3, 3: 5, 4: math.Inf(-1)}
+	if !reflect.DeepEqual(widths, want) {
+		t.Errorf("Expected widths %v, got %v", want, widths)
+	}
+	if nodes, _ := PathTo(predecessors, 0, 3); !reflect.DeepEqual(nodes, []int{0, 1, 3}) {
+		t.Errorf("Unexpected predecessors: %v", predecessors)
+	}
+
+	if _, _, err := WidestPath(g, 0, 4); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath, got %v", err)
+	}
+}
+
+func TestMinimaxPath(t *testing.T) {
+	g := NewAdjacencyList(false, true)
+	for i := 0; i <= 3; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 3, 9)
+	g.AddEdge(0, 2, 4)
+	g.AddEdge(2, 3, 4)
+
+	path, value, err := MinimaxPath(g, 0, 3)
+	if err != nil {
+		t.Fatalf("MinimaxPath failed: %v", err)
+	}
+	if value != 4 || !reflect.DeepEqual(path.Nodes, []int{0, 2, 3}) {
+		t.Errorf("Expected path [0 2 3] with maximum 4, got %v with %v", path.Nodes, value)
+	}
+	values, _, err := MinimaxPaths(g, 3)
+	if err != nil {
+		t.Fatalf("MinimaxPaths failed: %v", err)
+	}
+	if values[1] != 4 || values[3] != math.Inf(-1) {
+		t.Errorf("Unexpected minimax values: %v", values)
+	}
+}
+
+// 與暴力枚舉所有簡單路徑的結果比較
+func TestBottleneckPathsBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(44))
+	g := NewAdjacencyList(true, true)
+	const n = 8
+	for i := 0; i < n; i++ {
+		g.AddNode(i)
+	}
+	for i := 0; i < 20; i++ {
+		g.AddEdge(rng.Intn(n), rng.Intn(n), float64(rng.Intn(21)-10))
+	}
+
+	bestWidth := map[int]float64{}
+	bestMinimax := map[int]float64{}
+	var walk func(node int, width, maximum float64, visited map[int]bool)
+	walk = func(node int, width, maximum float64, visited map[int]bool) {
+		if w, ok := bestWidth[node]; !ok || width > w {
+			bestWidth[node] = width
+		}
+		if m, ok := bestMinimax[node]; !ok || maximum < m {
+			bestMinimax[node] = maximum
+		}
+		neighbors, _ := g.GetNeighbors(node)
+		for _, edge := range neighbors {
+			if !visited[edge.To] {
+				visited[edge.To] = true
+				walk(edge.To, math.Min(width, edge.Weight), math.Max(maximum, edge.Weight), visited)
+				delete(visited, edge.To)
+			}
+		}
+	}
+	walk(0, math.Inf(1), math.Inf(-1), map[int]bool{0: true})
+
+	widths, _, _ := WidestPaths(g, 0)
+	values, _, _ := MinimaxPaths(g, 0)
+	for node := 0; node < n; node++ {
+		if w, ok := bestWidth[node]; ok && widths[node] != w {
+			t.Errorf("Width of %d: got %v, want %v", node, widths[node], w)
+		}
+		if m, ok := bestMinimax[node]; ok && values[node] != m {
+			t.Errorf("Minimax of %d: got %v, want %v", node, values[node], m)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ChinesePostman 解決中國郵差問題（route inspection）：求經過每條邊至少一次並回到起點的最短封閉路線，
+// 例如掃街或道路巡檢。做法是複製部分邊使圖成為歐拉圖，再以 Hierholzer 算法建立歐拉迴路：
+//   - 無向圖：以 Dijkstra 計算奇數度節點之間的最短路徑，並以最小權重完美匹配兩兩配對，
+//     複製配對之間最短路徑上的邊。奇數度節點超過 20 個時改用貪婪匹配，結果不保證最短。
+//   - 有向圖：以最小成本流將出度少於入度的節點的多餘流量送到出度多於入度的節點，
+//     流經的邊即為需要重複走的邊。
+//
+// 需要非負權重的加權圖；自訂成本（包括為無權圖指定成本）請透過 WithWeights 視圖傳入，
+// 匹配與最小成本流都會使用視圖的權重。孤立節點不影響結果。
+//
+// Returns:
+// - A closed walk starting at the smallest node with edges. Path.Edges lists every edge
+// traversal (parallel edges keep their own weight) and Path.Cost is the total cost.
+// - An error wrapping ErrNoPath if some edges cannot be reached from the others
+// (for directed graphs, if the edges are not strongly connected).
+//
+// Example:
+// route, err := ChinesePostman(streets)
+// fmt.Println(route.Nodes, route.Cost)
+func ChinesePostman(g Graph) (*Path, error) {
+	if !g.IsWeighted() {
+		return nil, fmt.Errorf("Chinese postman requires a weighted graph")
+	}
+	multi, err := newMultigraph(g)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range multi.edges {
+		if err := checkEdgeWeight(e.from, Edge{To: e.to, Weight: e.weight}); err != nil {
+			return nil, err
+		}
+	}
+	if len(multi.edges) == 0 {
+		return &Path{Nodes: []int{}, Edges: []Edge{}}, nil
+	}
+
+	// 所有的邊必須位於同一個（弱）連通分量中
+	multi.start = multi.edges[0].from
+	for node := range multi.adj {
+		multi.start = min(multi.start, node)
+	}
+	if unreached := multi.unreachable(); len(unreached) > 0 {
+		return nil, fmt.Errorf("%w: edges are not all in one connected component; unreachable nodes %v", ErrNoPath, unreached)
+	}
+
+	if multi.directed {
+		err = balanceByFlow(g, multi)
+	} else {
+		err = pairOddNodes(g, multi)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, ids := multi.hierholzer(multi.start)
+	route := &Path{Nodes: nodes, Edges: make([]Edge, len(ids))}
+	for i, id := range ids {
+		route.Edges[i] = Edge{To: nodes[i+1], Weight: multi.edges[id].weight}
+		route.Cost += multi.edges[id].weight
+	}
+	return route, nil
+}
+
+// pairOddNodes 以最小權重完美匹配配對無向圖的奇數度節點，並複製配對之間最短路徑上的邊。
+// 權重已由 ChinesePostman 檢查過，因此直接使用 dijkstraSearch，不再逐次檢查整張圖。
+func pairOddNodes(g Graph, multi *multigraph) error {
+	var odd []int
+	for node, refs := range multi.adj {
+		if len(refs)%2 == 1 {
+			odd = append(odd, node)
+		}
+	}
+	sort.Ints(odd)
+
+	guard := SearchOptions{}.newGuard()
+	defer guard.release()
+	distances := make([]map[int]float64, len(odd))
+	predecessors := make([]map[int]int, len(odd))
+	for i, node := range odd {
+		var err error
+		if distances[i], predecessors[i], err = dijkstraSearch(g, node, node, false, guard); err != nil {
+			return err
+		}
+	}
+
+	pairs := minWeightPerfectMatching(len(odd), func(a, b int) float64 { return distances[a][odd[b]] })
+	for _, pair := range pairs {
+		nodes, err := PathTo(predecessors[pair[0]], odd[pair[0]], odd[pair[1]])
+		if err != nil {
+			return err
+		}
+		path, err := newPath(g, nodes)
+		if err != nil {
+			return err
+		}
+		for i, edge := range path.Edges {
+			multi.addEdge(nodes[i], edge.To, edge.Weight)
+		}
+	}
+	return nil
+}
+
+// balanceByFlow 以最小成本流決定有向圖中需要重複走的邊，使每個節點的出度等於入度
+func balanceByFlow(g Graph, multi *multigraph) error {
+	nodes := g.GetNodes()
+	sort.Ints(nodes)
+	index := make(map[int]int, len(nodes))
+	for i, node := range nodes {
+		index[node] = i
+	}
+
+	// 入度多於出度的節點需要額外的出邊，是流量的來源
+	excess := make([]int, len(nodes))
+	for _, e := range multi.edges {
+		excess[index[e.to]]++
+		excess[index[e.from]]--
+	}
+	source, sink := len(nodes), len(nodes)+1
+	network := newFlowNetwork(len(nodes) + 2)
+	need := 0
+	for i, x := range excess {
+		if x > 0 {
+			network.addArc(source, i, x, 0)
+			need += x
+		} else if x < 0 {
+			network.addArc(i, sink, -x, 0)
+		}
+	}
+	if need == 0 {
+		return nil
+	}
+
+	// 原圖的每對相鄰節點只需要一條權重最小的弧，容量不受限制（need 已足夠）
+	type pair struct{ from, to int }
+	cheapest := make(map[pair]float64)
+	for _, e := range multi.edges {
+		p := pair{from: index[e.from], to: index[e.to]}
+		if w, ok := cheapest[p]; e.from != e.to && (!ok || e.weight < w) {
+			cheapest[p] = e.weight
+		}
+	}
+	pairs := make([]pair, 0, len(cheapest))
+	for p := range cheapest {
+		pairs = append(pairs, p)
+	}
+	sort.Slice(pairs, func(a, b int) bool {
+		if pairs[a].from != pairs[b].from {
+			return pairs[a].from < pairs[b].from
+		}
+		return pairs[a].to < pairs[b].to
+	})
+	arcs := make([]int, len(pairs))
+	for k, p := range pairs {
+		arcs[k] = network.addArc(p.from, p.to, need, cheapest[p])
+	}
+
+	if flow := network.minCostFlow(source, sink, need); flow < need {
+		return fmt.Errorf("%w: the edges are not strongly connected, so some edges cannot be followed by a return", ErrNoPath)
+	}
+	for k, p := range pairs {
+		for copies := network.flow(p.from, arcs[k]); copies > 0; copies-- {
+			multi.addEdge(nodes[p.from], nodes[p.to], cheapest[p])
+		}
+	}
+	return nil
+}
+
+// flowNetwork 是以殘餘圖表示的最小成本流網路
+type flowNetwork struct {
+	arcs [][]flowArc
+}
+
+// flowArc 是殘餘圖中的一條弧，rev 為反向弧在 arcs[to] 中的索引
+type flowArc struct {
+	to, rev  int
+	capacity int
+	cost     float64
+	original int // 建立時的容量，反向弧為 0
+}
+
+func newFlowNetwork(n int) *flowNetwork {
+	return &flowNetwork{arcs: make([][]flowArc, n)}
+}
+
+// addArc 加入一條弧與其反向弧，返回弧在 arcs[from] 中的索引
+func (f *flowNetwork) addArc(from, to, capacity int, cost float64) int {
+	f.arcs[from] = append(f.arcs[from], flowArc{to: to, rev: len(f.arcs[to]), capacity: capacity, cost: cost, original: capacity})
+	f.arcs[to] = append(f.arcs[to], flowArc{to: from, rev: len(f.arcs[from]) - 1, cost: -cost})
+	return len(f.arcs[from]) - 1
+}
+
+// flow 返回弧 arcs[from][k] 上的流量
+func (f *flowNetwork) flow(from, k int) int {
+	arc := f.arcs[from][k]
+	return arc.original - arc.capacity
+}
+
+// minCostFlow 以逐次最短路徑算法從 source 送出最多 need 單位的流量，返回實際送出的流量。
+// 殘餘圖含有負成本的反向弧，因此以 SPFA 尋找最短增廣路徑。
+func (f *flowNetwork) minCostFlow(source, sink, need int) int {
+	n := len(f.arcs)
+	total := 0
+	for total < need {
+		dist := make([]float64, n)
+		for i := range dist {
+			dist[i] = math.Inf(1)
+		}
+		dist[source] = 0
+		prevNode, prevArc := make([]int, n), make([]int, n)
+		inQueue := make([]bool, n)
+		queue := []int{source}
+		inQueue[source] = true
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			inQueue[u] = false
+			for k, arc := range f.arcs[u] {
+				if arc.capacity > 0 && dist[u]+arc.cost < dist[arc.to] {
+					dist[arc.to] = dist[u] + arc.cost
+					prevNode[arc.to], prevArc[arc.to] = u, k
+					if !inQueue[arc.to] {
+						inQueue[arc.to] = true
+						queue = append(queue, arc.to)
+					}
+				}
+			}
+		}
+		if math.IsInf(dist[sink], 1) {
+			break
+		}
+
+		// 沿最短路徑送出瓶頸容量
+		amount := need - total
+		for v := sink; v != source; v = prevNode[v] {
+			amount = min(amount, f.arcs[prevNode[v]][prevArc[v]].capacity)
+		}
+		for v := sink; v != source; v = prevNode[v] {
+			arc := &f.arcs[prevNode[v]][prevArc[v]]
+			arc.capacity -= amount
+			f.arcs[v][arc.rev].capacity += amount
+		}
+		total += amount
+	}
+	return total
+}
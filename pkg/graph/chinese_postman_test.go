@@ -0,0 +1,195 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// checkPostmanRoute 驗證路線是封閉的、只經過存在的邊，並且每條邊至少經過一次
+func checkPostmanRoute(t *testing.T, g *AdjacencyList, route *Path) {
+	t.Helper()
+	key := func(u, v int) [2]int {
+		if !g.IsDirected() && v < u {
+			u, v = v, u
+		}
+		return [2]int{u, v}
+	}
+	required := make(map[[2]int]int)
+	for _, u := range g.GetNodes() {
+		neighbors, _ := g.GetNeighbors(u)
+		for _, edge := range neighbors {
+			if g.IsDirected() || u <= edge.To {
+				required[key(u, edge.To)]++
+			}
+		}
+	}
+	if !g.IsDirected() {
+		// 無向圖的自環在鄰居列表中出現兩次
+		for k, count := range required {
+			if k[0] == k[1] {
+				required[k] = count / 2
+			}
+		}
+	}
+
+	nodes := route.Nodes
+	if len(nodes) == 0 || nodes[0] != nodes[len(nodes)-1] || len(route.Edges) != len(nodes)-1 {
+		t.Fatalf("route %v is not a closed walk", nodes)
+	}
+	cost := 0.0
+	for i, edge := range route.Edges {
+		u, v := nodes[i], nodes[i+1]
+		if edge.To != v || !g.HasEdge(u, v) {
+			t.Fatalf("route %v uses missing edge %d -> %d", nodes, u, v)
+		}
+		required[key(u, v)]--
+		cost += edge.Weight
+	}
+	for k, count := range required {
+		if count > 0 {
+			t.Errorf("route %v misses edge %d -> %d", nodes, k[0], k[1])
+		}
+	}
+	if math.Abs(cost-route.Cost) > 1e-9 {
+		t.Errorf("route reports cost %v, edges sum to %v", route.Cost, cost)
+	}
+}
+
+func TestChinesePostmanUndirected(t *testing.T) {
+	// 正方形 0-1-2-3 加上對角線 0-2：節點 0 與 2 為奇數度，需重走 0-1-2（成本 2）
+	g := NewAdjacencyList(false, true)
+	for i := 0; i < 5; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 0, 1)
+	g.AddEdge(0, 2, 3)
+
+	route, err := ChinesePostman(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkPostmanRoute(t, g, route)
+	if route.Cost != 9 {
+		t.Errorf("expected cost 9, got %v", route.Cost)
+	}
+	if route.Nodes[0] != 0 {
+		t.Errorf("route should start at node 0, got %v", route.Nodes)
+	}
+
+	// 加入平行邊與自環後，它們同樣必須經過並計入度數
+	g.AddEdge(1, 3, 4)
+	g.AddEdge(1, 3, 2)
+	g.AddEdge(4, 4, 1)
+	g.AddEdge(3, 4, 5)
+	g.AddEdge(4, 0, 1)
+	route, err = ChinesePostman(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkPostmanRoute(t, g, route)
+	// 奇數度節點變為 2（度數 3）與 3（度數 5），需重走 2-3（成本 1）
+	if total := 1 + 1 + 1 + 1 + 3 + 4 + 2 + 1 + 5 + 1.0; route.Cost != total+1 {
+		t.Errorf("expected cost %v, got %v", total+1, route.Cost)
+	}
+}
+
+func TestChinesePostmanDirected(t *testing.T) {
+	// 環 0 -> 1 -> 2 -> 0 加上捷徑 0 -> 2：節點 2 的入度多 1，需重走 2 -> 0
+	g := NewAdjacencyList(true, true)
+	for i := 0; i < 4; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 0, 1)
+	g.AddEdge(0, 2, 1)
+	route, err := ChinesePostman(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkPostmanRoute(t, g, route)
+	if route.Cost != 5 {
+		t.Errorf("expected cost 5, got %v", route.Cost)
+	}
+
+	// 兩條捷徑 0 -> 2：從 2 回到 0 經由 3 比直接的 2 -> 0（成本 5）便宜
+	g = NewAdjacencyList(true, true)
+	for i := 0; i < 4; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 0, 5)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 0, 1)
+	route, err = ChinesePostman(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkPostmanRoute(t, g, route)
+	// 邊的總和為 11，節點 2 多出一條入邊，需重走 2 -> 3 -> 0
+	if route.Cost != 13 {
+		t.Errorf("expected cost 13, got %v", route.Cost)
+	}
+}
+
+func TestChinesePostmanErrors(t *testing.T) {
+	// 有向圖不是強連通的
+	g := NewAdjacencyList(true, true)
+	g.AddNode(0)
+	g.AddNode(1)
+	g.AddEdge(0, 1, 1)
+	if _, err := ChinesePostman(g); !errors.Is(err, ErrNoPath) {
+		t.Errorf("expected ErrNoPath, got %v", err)
+	}
+
+	// 兩條不相連的邊
+	g = NewAdjacencyList(false, true)
+	for i := 0; i < 4; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(2, 3, 1)
+	if _, err := ChinesePostman(g); !errors.Is(err, ErrNoPath) {
+		t.Errorf("expected ErrNoPath, got %v", err)
+	}
+
+	g.AddEdge(1, 2, -1)
+	if _, err := ChinesePostman(g); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+
+	// 無權圖需要透過 WithWeights 指定成本
+	grid, _ := buildGrid(3, 3, nil)
+	unweighted := NewAdjacencyList(false, false)
+	for _, node := range grid.GetNodes() {
+		unweighted.AddNode(node)
+	}
+	for _, node := range grid.GetNodes() {
+		neighbors, _ := grid.GetNeighbors(node)
+		for _, edge := range neighbors {
+			if node < edge.To {
+				unweighted.AddEdge(node, edge.To, 0)
+			}
+		}
+	}
+	if _, err := ChinesePostman(unweighted); err == nil {
+		t.Error("expected an error for an unweighted graph")
+	}
+	unit := func(from, to int, e Edge) float64 { return 1 }
+	route, err := ChinesePostman(WithWeights(unweighted, unit))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkPostmanRoute(t, grid, route)
+	// 3x3 網格有 12 條邊與 4 個奇數度的邊中點，兩兩相距 2
+	if route.Cost != 16 {
+		t.Errorf("expected cost 16, got %v", route.Cost)
+	}
+}
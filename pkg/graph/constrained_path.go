@@ -0,0 +1,277 @@
+package graph
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+// ResourceFunc 返回經過邊 from -> to 所消耗的次要資源量，例如過路費或行駛時間。
+// 資源量必須非負；可以透過閉包從外部的屬性表查詢。
+type ResourceFunc func(from, to int, edge Edge) float64
+
+// ResourceConstraint 限制路徑上某項資源的總消耗量
+type ResourceConstraint struct {
+	Resource ResourceFunc // 每條邊的資源消耗量
+	Limit    float64      // 路徑上的總消耗量上限（包含）
+}
+
+// ConstrainedPathOptions 控制受限最短路徑搜索
+type ConstrainedPathOptions struct {
+	MaxHops     int                  // 路徑最多包含的邊數，小於等於 0 表示不限制
+	Constraints []ResourceConstraint // 資源限制，可以有多項
+}
+
+// ConstrainedPath 是帶有資源消耗量的路徑
+type ConstrainedPath struct {
+	Path                // 路徑、邊與成本（Edge.Weight 的總和）
+	Resources []float64 // 每項資源限制對應的總消耗量，順序與 Constraints 相同
+}
+
+// ConstrainedShortestPath 在滿足最多跳數與資源上限的前提下，找出成本（Edge.Weight 總和）最小的路徑。
+// 使用標籤設定（label-setting）算法：每個節點保存多個互不支配的標籤
+// （成本、各項資源、跳數），依成本遞增展開，第一個到達終點的標籤即為最佳解。
+//
+// Example:
+// toll := func(from, to int, e Edge) float64 { return tolls[[2]int{from, to}] }
+// path, _ := ConstrainedShortestPath(g, 1, 9, ConstrainedPathOptions{
+//
+//	Constraints: []ResourceConstraint{{Resource: toll, Limit: 50}},
+//
+// })
+func ConstrainedShortestPath(g Graph, start, target int, opts ConstrainedPathOptions) (*ConstrainedPath, error) {
+	search, err := newLabelSearch(g, start, target, opts)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		label, err := search.next()
+		if err != nil {
+			return nil, err
+		}
+		if label == nil {
+			return nil, fmt.Errorf("%w: from %d to %d within the given constraints", ErrNoPath, start, target)
+		}
+		if label.node == target {
+			return label.path(search.numResources), nil
+		}
+	}
+}
+
+// HopLimitedShortestPath 找出最多包含 maxHops 條邊、成本最小的路徑
+func HopLimitedShortestPath(g Graph, start, target, maxHops int) (*Path, error) {
+	if maxHops <= 0 {
+		return nil, fmt.Errorf("maxHops must be positive, got %d", maxHops)
+	}
+	path, err := ConstrainedShortestPath(g, start, target, ConstrainedPathOptions{MaxHops: maxHops})
+	if err != nil {
+		return nil, err
+	}
+	return &path.Path, nil
+}
+
+// ParetoPaths 返回從 start 到 target 所有 Pareto 最佳的可行路徑：
+// 不存在另一條路徑在成本與每項資源上都不比它差（且至少一項更好）。
+// 結果依成本遞增排序，可用於在成本與資源之間權衡。
+func ParetoPaths(g Graph, start, target int, opts ConstrainedPathOptions) ([]*ConstrainedPath, error) {
+	search, err := newLabelSearch(g, start, target, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []*pathLabel{}
+	for {
+		label, err := search.next()
+		if err != nil {
+			return nil, err
+		}
+		if label == nil {
+			break
+		}
+		if label.node == target {
+			candidates = append(candidates, label)
+		}
+	}
+
+	// 只保留不被其他路徑支配的標籤（不考慮跳數）；完全相同的路徑只保留先找到者
+	front := []*pathLabel{}
+	for i, label := range candidates {
+		dominated := false
+		for j, other := range candidates {
+			if i != j && other.dominates(label, false) && (!label.dominates(other, false) || j < i) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			front = append(front, label)
+		}
+	}
+
+	if len(front) == 0 {
+		return nil, fmt.Errorf("%w: from %d to %d within the given constraints", ErrNoPath, start, target)
+	}
+	paths := make([]*ConstrainedPath, len(front))
+	for i, label := range front {
+		paths[i] = label.path(search.numResources)
+	}
+	return paths, nil
+}
+
+// pathLabel 是標籤設定算法中的一個部分路徑
+type pathLabel struct {
+	node      int
+	cost      float64
+	resources []float64
+	hops      int
+	parent    *pathLabel
+	edge      Edge // 從 parent 到此標籤所經過的邊
+	dead      bool // 是否已被同一節點上的其他標籤支配
+}
+
+// dominates 判斷 l 是否支配 other：成本、各項資源（以及跳數，若 useHops 為 true）都不比 other 差
+func (l *pathLabel) dominates(other *pathLabel, useHops bool) bool {
+	if l.cost > other.cost || (useHops && l.hops > other.hops) {
+		return false
+	}
+	for i := range l.resources {
+		if l.resources[i] > other.resources[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// path 沿著父標籤重建路徑
+func (l *pathLabel) path(numResources int) *ConstrainedPath {
+	labels := []*pathLabel{}
+	for current := l; current != nil; current = current.parent {
+		labels = append(labels, current)
+	}
+
+	p := &ConstrainedPath{Resources: make([]float64, numResources)}
+	copy(p.Resources, l.resources)
+	p.Cost = l.cost
+	for i := len(labels) - 1; i >= 0; i-- {
+		p.Nodes = append(p.Nodes, labels[i].node)
+		if labels[i].parent != nil {
+			p.Edges = append(p.Edges, labels[i].edge)
+		}
+	}
+	return p
+}
+
+// labelSearch 保存標籤設定算法的狀態
+type labelSearch struct {
+	graph        Graph
+	opts         ConstrainedPathOptions
+	target       int
+	numResources int
+	labels       map[int][]*pathLabel // 每個節點上互不支配的標籤
+	queue        []*pathLabel         // 由 pq 排序的標籤，Item.value 為索引
+	pq           *PriorityQueue
+}
+
+func newLabelSearch(g Graph, start, target int, opts ConstrainedPathOptions) (*labelSearch, error) {
+	if !g.IsWeighted() {
+		return nil, fmt.Errorf("constrained shortest path requires a weighted graph")
+	}
+	if _, err := g.GetNeighbors(start); err != nil {
+		return nil, err
+	}
+	if _, err := g.GetNeighbors(target); err != nil {
+		return nil, err
+	}
+	if err := precheckNonNegativeWeights(g); err != nil {
+		return nil, err
+	}
+	for i, c := range opts.Constraints {
+		if c.Resource == nil {
+			return nil, fmt.Errorf("constraint %d has no resource function", i)
+		}
+	}
+
+	s := &labelSearch{
+		graph:        g,
+		opts:         opts,
+		target:       target,
+		numResources: len(opts.Constraints),
+		labels:       make(map[int][]*pathLabel),
+		pq:           NewPriorityQueue(),
+	}
+	s.push(&pathLabel{node: start, resources: make([]float64, s.numResources)})
+	return s, nil
+}
+
+// next 取出成本最小的有效標籤並展開，沒有更多標籤時返回 nil
+func (s *labelSearch) next() (*pathLabel, error) {
+	for s.pq.Len() > 0 {
+		label := s.queue[heap.Pop(s.pq).(*Item).value]
+		if label.dead {
+			continue
+		}
+		if label.node == s.target {
+			return label, nil // 終點標籤不需要再展開
+		}
+		if s.opts.MaxHops > 0 && label.hops >= s.opts.MaxHops {
+			return label, nil
+		}
+
+		neighbors, err := s.graph.GetNeighbors(label.node)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range neighbors {
+			if err := checkEdgeWeight(label.node, edge); err != nil {
+				return nil, err
+			}
+			child := &pathLabel{
+				node:      edge.To,
+				cost:      label.cost + edge.Weight,
+				resources: make([]float64, s.numResources),
+				hops:      label.hops + 1,
+				parent:    label,
+				edge:      edge,
+			}
+			feasible := true
+			for i, c := range s.opts.Constraints {
+				used := c.Resource(label.node, edge.To, edge)
+				if used < 0 {
+					return nil, fmt.Errorf("resource %d of edge %d -> %d is negative: %v", i, label.node, edge.To, used)
+				}
+				child.resources[i] = label.resources[i] + used
+				if child.resources[i] > c.Limit {
+					feasible = false
+				}
+			}
+			if feasible {
+				s.push(child)
+			}
+		}
+		return label, nil
+	}
+	return nil, nil
+}
+
+// push 在標籤不被同一節點上的既有標籤支配時加入，並淘汰被它支配的標籤
+func (s *labelSearch) push(label *pathLabel) {
+	useHops := s.opts.MaxHops > 0
+	existing := s.labels[label.node]
+	for _, other := range existing {
+		if other.dominates(label, useHops) {
+			return
+		}
+	}
+
+	kept := existing[:0]
+	for _, other := range existing {
+		if label.dominates(other, useHops) {
+			other.dead = true
+			continue
+		}
+		kept = append(kept, other)
+	}
+	s.labels[label.node] = append(kept, label)
+
+	s.queue = append(s.queue, label)
+	heap.Push(s.pq, &Item{value: len(s.queue) - 1, priority: label.cost, tiebreak: float64(label.hops)})
+}
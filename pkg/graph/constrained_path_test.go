@@ -0,0 +1,91 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConstrainedShortestPath(t *testing.T) {
+	// 1 -> 4 有三條路線：
+	// 1-2-4：成本 2，過路費 10
+	// 1-3-4：成本 4，過路費 3
+	// 1-4  ：成本 9，過路費 0
+	g := NewAdjacencyList(true, true)
+	for i := 1; i <= 4; i++ {
+		g.AddNode(i)
+	}
+	tolls := map[[2]int]float64{}
+	add := func(from, to int, cost, toll float64) {
+		g.AddEdge(from, to, cost)
+		tolls[[2]int{from, to}] = toll
+	}
+	add(1, 2, 1, 5)
+	add(2, 4, 1, 5)
+	add(1, 3, 2, 1)
+	add(3, 4, 2, 2)
+	add(1, 4, 9, 0)
+	toll := func(from, to int, edge Edge) float64 { return tolls[[2]int{from, to}] }
+
+	cases := []struct {
+		limit float64
+		cost  float64
+	}{
+		{100, 2},
+		{5, 4},
+		{2, 9},
+	}
+	for _, c := range cases {
+		path, err := ConstrainedShortestPath(g, 1, 4, ConstrainedPathOptions{
+			Constraints: []ResourceConstraint{{Resource: toll, Limit: c.limit}},
+		})
+		if err != nil {
+			t.Fatalf("ConstrainedShortestPath failed: %v", err)
+		}
+		if path.Cost != c.cost || path.Resources[0] > c.limit {
+			t.Errorf("Limit %f: got cost %f toll %f, want cost %f", c.limit, path.Cost, path.Resources[0], c.cost)
+		}
+	}
+
+	// 跳數限制
+	path, err := HopLimitedShortestPath(g, 1, 4, 1)
+	if err != nil || path.Cost != 9 {
+		t.Errorf("Expected direct edge with cost 9, got %+v, %v", path, err)
+	}
+	if _, err := HopLimitedShortestPath(g, 1, 3, 0); err == nil {
+		t.Errorf("Expected error for non-positive maxHops")
+	}
+
+	// Pareto 最佳路徑集合
+	front, err := ParetoPaths(g, 1, 4, ConstrainedPathOptions{
+		Constraints: []ResourceConstraint{{Resource: toll, Limit: 100}},
+	})
+	if err != nil {
+		t.Fatalf("ParetoPaths failed: %v", err)
+	}
+	if len(front) != 3 || front[0].Cost != 2 || front[1].Cost != 4 || front[2].Cost != 9 {
+		t.Errorf("Unexpected Pareto front: %+v", front)
+	}
+
+	// 無可行解
+	_, err = ConstrainedShortestPath(g, 1, 4, ConstrainedPathOptions{
+		MaxHops:     1,
+		Constraints: []ResourceConstraint{{Resource: func(int, int, Edge) float64 { return 1 }, Limit: 0.5}},
+	})
+	if !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath, got %v", err)
+	}
+}
+
+func TestConstrainedShortestPathNegativeWeight(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	for i := 0; i < 3; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, -1)
+	for _, graph := range []Graph{g, plainGraph{g}} {
+		if _, err := HopLimitedShortestPath(graph, 0, 2, 5); !errors.Is(err, ErrNegativeWeight) {
+			t.Errorf("Expected ErrNegativeWeight, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,415 @@
+package graph
+
+import (
+	"container/heap"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// chWitnessLimit 限制每次見證搜索（witness search）最多確定的節點數。
+// 搜索提前結束只會多加入一些不必要的捷徑，不影響查詢結果的正確性。
+const chWitnessLimit = 64
+
+// chFormatVersion 是序列化格式的版本，格式改變時遞增
+const chFormatVersion = 1
+
+// ContractionHierarchy 是加權圖的收縮層次（Contraction Hierarchies）索引。
+// 預處理時依重要性逐一收縮節點，並加入保持最短距離所需的捷徑；
+// 查詢時只需從兩端沿排名遞增的弧進行雙向搜索，展開的節點數遠少於 Dijkstra。
+//
+// 索引建立後與原圖無關，原圖修改後需要重新建立。
+type ContractionHierarchy struct {
+	rank map[int]int      // 節點的收縮順序，越晚收縮排名越高
+	arcs map[[2]int]chArc // 每對節點之間最短的原始邊或捷徑
+	up   map[int][]chArc  // 從節點通往排名較高節點的弧（正向搜索）
+	down map[int][]chArc  // 從排名較高節點進入此節點的弧（反向搜索）
+}
+
+// chArc 是收縮層次中的一條弧。欄位需要導出以便 gob 序列化。
+type chArc struct {
+	From, To int
+	Weight   float64
+	Via      int  // 捷徑所跳過的節點
+	Shortcut bool // 是否為捷徑（由 From -> Via -> To 兩條弧組成）
+}
+
+// BuildContractionHierarchy 對加權圖進行預處理，建立收縮層次。
+// 節點依「邊差」（需加入的捷徑數減去移除的邊數）加上已收縮鄰居數的順序收縮，
+// 優先級以延遲更新的方式維護。
+//
+// Returns:
+// - The hierarchy, whose ShortestPath returns the same Path as ShortestPath(g, start, target).
+// - An error if the graph is unweighted or has negative edges.
+//
+// Example:
+// ch, _ := BuildContractionHierarchy(g)
+// path, _ := ch.ShortestPath(1, 42)
+// fmt.Println(path.Nodes, path.Cost)
+func BuildContractionHierarchy(g *AdjacencyList) (*ContractionHierarchy, error) {
+	if !g.IsWeighted() {
+		return nil, fmt.Errorf("contraction hierarchy requires a weighted graph")
+	}
+	if err := checkNonNegativeWeights(g); err != nil {
+		return nil, err
+	}
+
+	b := &chBuilder{
+		out:                make(map[int]map[int]float64),
+		in:                 make(map[int]map[int]float64),
+		contractedNeighbor: make(map[int]int),
+		ch: &ContractionHierarchy{
+			rank: make(map[int]int),
+			arcs: make(map[[2]int]chArc),
+		},
+	}
+	nodes := g.GetNodes()
+	sort.Ints(nodes)
+	for _, u := range nodes {
+		b.out[u] = make(map[int]float64)
+		b.in[u] = make(map[int]float64)
+	}
+	for _, u := range nodes {
+		neighbors, err := g.GetNeighbors(u)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range neighbors {
+			if edge.To != u { // 自環不會出現在最短路徑上
+				b.addArc(chArc{From: u, To: edge.To, Weight: edge.Weight})
+			}
+		}
+	}
+
+	pq := NewPriorityQueue()
+	for _, v := range nodes {
+		heap.Push(pq, &Item{value: v, priority: b.priority(v, b.shortcuts(v)), tiebreak: float64(v)})
+	}
+	for pq.Len() > 0 {
+		v := heap.Pop(pq).(*Item).value
+		shortcuts := b.shortcuts(v)
+		// 延遲更新：若重新計算後的優先級不再是最小，放回隊列
+		if p := b.priority(v, shortcuts); pq.Len() > 0 && p > (*pq)[0].priority {
+			heap.Push(pq, &Item{value: v, priority: p, tiebreak: float64(v)})
+			continue
+		}
+		b.contract(v, shortcuts)
+	}
+
+	b.ch.index()
+	return b.ch, nil
+}
+
+// ShortestPath 返回從 start 到 target 的最短路徑，捷徑會展開為原圖中的邊。
+// 目標不可達時返回包裝了 ErrNoPath 的錯誤。
+func (ch *ContractionHierarchy) ShortestPath(start, target int) (*Path, error) {
+	for _, node := range []int{start, target} {
+		if _, ok := ch.rank[node]; !ok {
+			return nil, fmt.Errorf("node %d does not exist in the graph", node)
+		}
+	}
+
+	forward := newCHSide(start, ch.up, func(a chArc) int { return a.To })
+	backward := newCHSide(target, ch.down, func(a chArc) int { return a.From })
+	sides := [2]*chSide{forward, backward}
+
+	best := math.Inf(1)
+	meet, met := 0, false
+	for active := true; active; {
+		active = false
+		for i, side := range sides {
+			node, dist, ok := side.settle(best)
+			if !ok {
+				continue
+			}
+			active = true
+			if d, reached := sides[1-i].distances[node]; reached && dist+d < best {
+				best, meet, met = dist+d, node, true
+			}
+		}
+	}
+	if !met {
+		return nil, fmt.Errorf("%w: from %d to %d", ErrNoPath, start, target)
+	}
+
+	// 組合弧序列：start ... meet ... target
+	route := []chArc{}
+	for node := meet; node != start; {
+		arc := forward.predecessors[node]
+		route = append(route, arc)
+		node = arc.From
+	}
+	for i, j := 0, len(route)-1; i < j; i, j = i+1, j-1 {
+		route[i], route[j] = route[j], route[i]
+	}
+	for node := meet; node != target; {
+		arc := backward.predecessors[node]
+		route = append(route, arc)
+		node = arc.To
+	}
+
+	path := &Path{Nodes: []int{start}, Edges: []Edge{}}
+	for _, arc := range route {
+		ch.unpack(arc, path)
+	}
+	return path, nil
+}
+
+// Shortcuts 返回預處理時加入的捷徑數量
+func (ch *ContractionHierarchy) Shortcuts() int {
+	count := 0
+	for _, arc := range ch.arcs {
+		if arc.Shortcut {
+			count++
+		}
+	}
+	return count
+}
+
+// WriteTo 將索引以 gob 格式寫入 w，實作 io.WriterTo
+func (ch *ContractionHierarchy) WriteTo(w io.Writer) (int64, error) {
+	snapshot := chSnapshot{Version: chFormatVersion, Rank: ch.rank, Arcs: make([]chArc, 0, len(ch.arcs))}
+	for _, arc := range ch.arcs {
+		snapshot.Arcs = append(snapshot.Arcs, arc)
+	}
+	sort.Slice(snapshot.Arcs, func(i, j int) bool {
+		a, b := snapshot.Arcs[i], snapshot.Arcs[j]
+		return a.From < b.From || (a.From == b.From && a.To < b.To)
+	})
+
+	cw := &countingWriter{w: w}
+	err := gob.NewEncoder(cw).Encode(snapshot)
+	return cw.n, err
+}
+
+// ReadContractionHierarchy 讀取由 WriteTo 寫入的索引
+func ReadContractionHierarchy(r io.Reader) (*ContractionHierarchy, error) {
+	var snapshot chSnapshot
+	if err := gob.NewDecoder(r).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("read contraction hierarchy: %w", err)
+	}
+	if snapshot.Version != chFormatVersion {
+		return nil, fmt.Errorf("unsupported contraction hierarchy format version %d", snapshot.Version)
+	}
+
+	ch := &ContractionHierarchy{
+		rank: snapshot.Rank,
+		arcs: make(map[[2]int]chArc, len(snapshot.Arcs)),
+	}
+	if ch.rank == nil {
+		ch.rank = make(map[int]int)
+	}
+	for _, arc := range snapshot.Arcs {
+		_, fromOk := ch.rank[arc.From]
+		_, toOk := ch.rank[arc.To]
+		if !fromOk || !toOk {
+			return nil, fmt.Errorf("arc %d -> %d refers to an unknown node", arc.From, arc.To)
+		}
+		ch.arcs[[2]int{arc.From, arc.To}] = arc
+	}
+	ch.index()
+	return ch, nil
+}
+
+// chSnapshot 是索引的序列化格式；up/down 可由排名與弧重建，因此不需要保存
+type chSnapshot struct {
+	Version int
+	Rank    map[int]int
+	Arcs    []chArc
+}
+
+// countingWriter 記錄寫入的位元組數
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// index 依排名將弧分配到正向與反向的搜索圖
+func (ch *ContractionHierarchy) index() {
+	ch.up = make(map[int][]chArc)
+	ch.down = make(map[int][]chArc)
+	for _, arc := range ch.arcs {
+		if ch.rank[arc.From] < ch.rank[arc.To] {
+			ch.up[arc.From] = append(ch.up[arc.From], arc)
+		} else {
+			ch.down[arc.To] = append(ch.down[arc.To], arc)
+		}
+	}
+}
+
+// unpack 將弧（可能是捷徑）展開為原圖中的邊並附加到路徑
+func (ch *ContractionHierarchy) unpack(arc chArc, path *Path) {
+	stack := []chArc{arc}
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !top.Shortcut {
+			path.Nodes = append(path.Nodes, top.To)
+			path.Edges = append(path.Edges, Edge{To: top.To, Weight: top.Weight})
+			path.Cost += top.Weight
+			continue
+		}
+		// 先處理 From -> Via，因此後放入堆疊
+		stack = append(stack, ch.arcs[[2]int{top.Via, top.To}], ch.arcs[[2]int{top.From, top.Via}])
+	}
+}
+
+// chSide 是收縮層次查詢其中一側的搜索狀態
+type chSide struct {
+	arcs         map[int][]chArc
+	next         func(a chArc) int // 沿弧前進到的節點
+	distances    map[int]float64
+	predecessors map[int]chArc
+	settled      map[int]bool
+	pq           *PriorityQueue
+}
+
+func newCHSide(root int, arcs map[int][]chArc, next func(a chArc) int) *chSide {
+	s := &chSide{
+		arcs:         arcs,
+		next:         next,
+		distances:    map[int]float64{root: 0},
+		predecessors: make(map[int]chArc),
+		settled:      make(map[int]bool),
+		pq:           NewPriorityQueue(),
+	}
+	heap.Push(s.pq, &Item{value: root, priority: 0})
+	return s
+}
+
+// settle 確定隊列中最近節點的距離並鬆弛其弧。
+// 若隊列已空或最小距離不小於 bound（不可能再改善結果），返回 false。
+func (s *chSide) settle(bound float64) (node int, dist float64, ok bool) {
+	for s.pq.Len() > 0 {
+		item := heap.Pop(s.pq).(*Item)
+		u := item.value
+		if s.settled[u] || item.priority > s.distances[u] {
+			continue // 過期的項目
+		}
+		if item.priority >= bound {
+			s.pq = NewPriorityQueue()
+			return 0, 0, false
+		}
+		s.settled[u] = true
+		for _, arc := range s.arcs[u] {
+			v := s.next(arc)
+			alt := s.distances[u] + arc.Weight
+			if d, reached := s.distances[v]; !reached || alt < d {
+				s.distances[v] = alt
+				s.predecessors[v] = arc
+				heap.Push(s.pq, &Item{value: v, priority: alt})
+			}
+		}
+		return u, s.distances[u], true
+	}
+	return 0, 0, false
+}
+
+// chBuilder 保存預處理過程中尚未收縮的剩餘圖
+type chBuilder struct {
+	out                map[int]map[int]float64 // 剩餘圖的出弧權重
+	in                 map[int]map[int]float64 // 剩餘圖的入弧權重
+	contractedNeighbor map[int]int             // 已收縮的鄰居數，使收縮均勻分布
+	ch                 *ContractionHierarchy
+}
+
+// addArc 加入弧；若已有權重不大於它的同向弧則忽略
+func (b *chBuilder) addArc(arc chArc) {
+	if w, ok := b.out[arc.From][arc.To]; ok && w <= arc.Weight {
+		return
+	}
+	b.out[arc.From][arc.To] = arc.Weight
+	b.in[arc.To][arc.From] = arc.Weight
+	b.ch.arcs[[2]int{arc.From, arc.To}] = arc
+}
+
+// priority 計算收縮 v 的優先級，數值越小越先收縮
+func (b *chBuilder) priority(v int, shortcuts []chArc) float64 {
+	return float64(len(shortcuts)-len(b.in[v])-len(b.out[v])) + float64(b.contractedNeighbor[v])
+}
+
+// shortcuts 返回收縮 v 時需要加入的捷徑：對每對 u -> v -> w，
+// 若不經過 v 找不到同樣短的見證路徑，就需要捷徑 u -> w。
+func (b *chBuilder) shortcuts(v int) []chArc {
+	result := []chArc{}
+	for u, inWeight := range b.in[v] {
+		limit, hasTarget := 0.0, false
+		for w, outWeight := range b.out[v] {
+			if w != u {
+				limit, hasTarget = math.Max(limit, inWeight+outWeight), true
+			}
+		}
+		if !hasTarget {
+			continue
+		}
+
+		witness := b.witnessSearch(u, v, limit)
+		for w, outWeight := range b.out[v] {
+			if w == u {
+				continue
+			}
+			if d, ok := witness[w]; ok && d <= inWeight+outWeight {
+				continue
+			}
+			result = append(result, chArc{From: u, To: w, Weight: inWeight + outWeight, Via: v, Shortcut: true})
+		}
+	}
+	return result
+}
+
+// witnessSearch 在剩餘圖中從 source 執行不經過 skip 的有限 Dijkstra，距離超過 limit 即停止
+func (b *chBuilder) witnessSearch(source, skip int, limit float64) map[int]float64 {
+	distances := map[int]float64{source: 0}
+	settled := make(map[int]bool)
+	pq := NewPriorityQueue()
+	heap.Push(pq, &Item{value: source, priority: 0})
+
+	for pq.Len() > 0 && len(settled) < chWitnessLimit {
+		item := heap.Pop(pq).(*Item)
+		u := item.value
+		if settled[u] || item.priority > distances[u] {
+			continue
+		}
+		if item.priority > limit {
+			break
+		}
+		settled[u] = true
+		for w, weight := range b.out[u] {
+			if w == skip {
+				continue
+			}
+			alt := distances[u] + weight
+			if d, ok := distances[w]; !ok || alt < d {
+				distances[w] = alt
+				heap.Push(pq, &Item{value: w, priority: alt})
+			}
+		}
+	}
+	return distances
+}
+
+// contract 收縮 v：記錄排名、加入捷徑，並將 v 從剩餘圖中移除
+func (b *chBuilder) contract(v int, shortcuts []chArc) {
+	b.ch.rank[v] = len(b.ch.rank)
+	for w := range b.out[v] {
+		delete(b.in[w], v)
+		b.contractedNeighbor[w]++
+	}
+	for u := range b.in[v] {
+		delete(b.out[u], v)
+		b.contractedNeighbor[u]++
+	}
+	delete(b.out, v)
+	delete(b.in, v)
+	for _, arc := range shortcuts {
+		b.addArc(arc)
+	}
+}
@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// checkPathMatches 驗證路徑的端點、邊與成本，並與 ShortestPath 的結果比較
+func checkPathMatches(t *testing.T, g *AdjacencyList, path *Path, start, target int) {
+	t.Helper()
+	expected, err := ShortestPath(g, start, target)
+	if err != nil {
+		t.Fatalf("ShortestPath failed: %v", err)
+	}
+	if math.Abs(path.Cost-expected.Cost) > 1e-9 {
+		t.Fatalf("Cost from %d to %d: got %f, want %f", start, target, path.Cost, expected.Cost)
+	}
+	if path.Nodes[0] != start || path.Nodes[len(path.Nodes)-1] != target || len(path.Edges) != len(path.Nodes)-1 {
+		t.Fatalf("Malformed path: %v", path.Nodes)
+	}
+	cost := 0.0
+	for i, edge := range path.Edges {
+		if edge.To != path.Nodes[i+1] || !g.HasEdge(path.Nodes[i], edge.To) {
+			t.Fatalf("Path uses missing edge %d -> %d", path.Nodes[i], edge.To)
+		}
+		cost += edge.Weight
+	}
+	if math.Abs(cost-path.Cost) > 1e-9 {
+		t.Fatalf("Edge weights sum to %f, path cost is %f", cost, path.Cost)
+	}
+}
+
+func TestContractionHierarchy(t *testing.T) {
+	rng := rand.New(rand.NewSource(41))
+	for _, directed := range []bool{true, false} {
+		g := NewAdjacencyList(directed, true)
+		const n = 300
+		for i := 0; i < n; i++ {
+			g.AddNode(i * 7) // 非連續的節點 ID
+		}
+		for i := 0; i < n*3; i++ {
+			g.AddEdge(rng.Intn(n)*7, rng.Intn(n)*7, float64(rng.Intn(20)))
+		}
+
+		ch, err := BuildContractionHierarchy(g)
+		if err != nil {
+			t.Fatalf("BuildContractionHierarchy failed: %v", err)
+		}
+		for trial := 0; trial < 200; trial++ {
+			start, target := rng.Intn(n)*7, rng.Intn(n)*7
+			path, err := ch.ShortestPath(start, target)
+			if _, expectedErr := ShortestPath(g, start, target); expectedErr != nil {
+				if !errors.Is(err, ErrNoPath) {
+					t.Errorf("Expected ErrNoPath from %d to %d, got %v", start, target, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("ShortestPath from %d to %d failed: %v", start, target, err)
+			}
+			checkPathMatches(t, g, path, start, target)
+		}
+	}
+}
+
+func TestContractionHierarchyGrid(t *testing.T) {
+	g, _ := buildGrid(30, 30, nil)
+	ch, err := BuildContractionHierarchy(g)
+	if err != nil {
+		t.Fatalf("BuildContractionHierarchy failed: %v", err)
+	}
+	for _, pair := range [][2]int{{0, 899}, {29, 870}, {450, 451}, {5, 5}} {
+		path, err := ch.ShortestPath(pair[0], pair[1])
+		if err != nil {
+			t.Fatalf("ShortestPath failed: %v", err)
+		}
+		checkPathMatches(t, g, path, pair[0], pair[1])
+	}
+	if _, err := ch.ShortestPath(0, 10000); err == nil {
+		t.Errorf("Expected error for unknown node")
+	}
+}
+
+func TestContractionHierarchySerialization(t *testing.T) {
+	g, _ := buildGrid(12, 12, map[int]bool{50: true, 62: true, 74: true})
+	ch, err := BuildContractionHierarchy(g)
+	if err != nil {
+		t.Fatalf("BuildContractionHierarchy failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	n, err := ch.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo reported %d bytes, wrote %d", n, buf.Len())
+	}
+	loaded, err := ReadContractionHierarchy(&buf)
+	if err != nil {
+		t.Fatalf("ReadContractionHierarchy failed: %v", err)
+	}
+	if loaded.Shortcuts() != ch.Shortcuts() {
+		t.Errorf("Shortcut count changed: %d vs %d", loaded.Shortcuts(), ch.Shortcuts())
+	}
+	for _, pair := range [][2]int{{0, 143}, {11, 132}, {49, 51}} {
+		path, err := loaded.ShortestPath(pair[0], pair[1])
+		if err != nil {
+			t.Fatalf("ShortestPath failed: %v", err)
+		}
+		checkPathMatches(t, g, path, pair[0], pair[1])
+	}
+	if _, err := loaded.ShortestPath(0, 50); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath for blocked node, got %v", err)
+	}
+
+	if _, err := ReadContractionHierarchy(bytes.NewReader([]byte("garbage"))); err == nil {
+		t.Errorf("Expected error for corrupt input")
+	}
+}
@@ -0,0 +1,326 @@
+package graph
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// CycleError 表示演算法需要有向無環圖，但圖中存在環
+type CycleError struct {
+	Cycle []int // 環上的節點，首尾為同一個節點，依邊的方向排列
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("graph contains a cycle: %v", e.Cycle)
+}
+
+// TopologicalSort 返回有向無環圖的拓撲排序（Kahn 算法），
+// 同時可以排序的節點依 ID 遞增排列，因此結果是確定的。
+//
+// Returns:
+// - The nodes in topological order.
+// - A *CycleError with the cycle as witness if the graph is not a DAG.
+//
+// Example:
+// order, err := TopologicalSort(g)
+// var cycleErr *CycleError
+//
+//	if errors.As(err, &cycleErr) {
+//		fmt.Println("cycle:", cycleErr.Cycle)
+//	}
+func TopologicalSort(g Graph) ([]int, error) {
+	if !g.IsDirected() {
+		return nil, fmt.Errorf("topological sort requires a directed graph")
+	}
+
+	nodes := g.GetNodes()
+	inDegree := make(map[int]int, len(nodes))
+	for _, from := range nodes {
+		neighbors, err := g.GetNeighbors(from)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range neighbors {
+			inDegree[edge.To]++
+		}
+	}
+
+	// 以最小堆保存入度為 0 的節點，使結果與 map 的遍歷順序無關
+	ready := NewPriorityQueue()
+	for _, node := range nodes {
+		if inDegree[node] == 0 {
+			heap.Push(ready, &Item{value: node, priority: float64(node)})
+		}
+	}
+
+	order := make([]int, 0, len(nodes))
+	for ready.Len() > 0 {
+		node := heap.Pop(ready).(*Item).value
+		order = append(order, node)
+
+		neighbors, err := g.GetNeighbors(node)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range neighbors {
+			inDegree[edge.To]--
+			if inDegree[edge.To] == 0 {
+				heap.Push(ready, &Item{value: edge.To, priority: float64(edge.To)})
+			}
+		}
+	}
+
+	if len(order) != len(nodes) {
+		cycle, _ := FindCycle(g)
+		return nil, &CycleError{Cycle: cycle}
+	}
+	return order, nil
+}
+
+// kahnOrder 以 FIFO 佇列執行 Kahn 算法，在 O(V+E) 時間內返回一個拓撲順序。
+// 與 TopologicalSort 不同，同時可以排序的節點不依 ID 排列，適合只需要任意拓撲順序的內部算法。
+func kahnOrder(g Graph) ([]int, error) {
+	if !g.IsDirected() {
+		return nil, fmt.Errorf("topological sort requires a directed graph")
+	}
+
+	nodes := g.GetNodes()
+	inDegree := make(map[int]int, len(nodes))
+	for _, from := range nodes {
+		neighbors, err := g.GetNeighbors(from)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range neighbors {
+			inDegree[edge.To]++
+		}
+	}
+
+	order := make([]int, 0, len(nodes))
+	for _, node := range nodes {
+		if inDegree[node] == 0 {
+			order = append(order, node)
+		}
+	}
+	// order 同時作為佇列，head 之前的節點已處理完畢
+	for head := 0; head < len(order); head++ {
+		neighbors, err := g.GetNeighbors(order[head])
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range neighbors {
+			inDegree[edge.To]--
+			if inDegree[edge.To] == 0 {
+				order = append(order, edge.To)
+			}
+		}
+	}
+
+	if len(order) != len(nodes) {
+		cycle, _ := FindCycle(g)
+		return nil, &CycleError{Cycle: cycle}
+	}
+	return order, nil
+}
+
+// DAGShortestPaths 依拓撲順序鬆弛每條邊，以線性時間 O(V+E) 計算有向無環圖上的單源最短路徑。
+// 距離相同時選擇 ID 較小的前驅節點，因此結果是確定的。
+// 支援負權重；無法到達的節點距離為 math.Inf(1)。圖中有環時返回 *CycleError。
+func DAGShortestPaths(g Graph, start int) (distances map[int]float64, predecessors map[int]int, err error) {
+	return dagPaths(g, start, false)
+}
+
+// DAGLongestPaths 以線性時間計算有向無環圖上從 start 出發的最長路徑，
+// 無法到達的節點距離為 math.Inf(-1)。圖中有環時返回 *CycleError。
+func DAGLongestPaths(g Graph, start int) (distances map[int]float64, predecessors map[int]int, err error) {
+	return dagPaths(g, start, true)
+}
+
+// dagPaths 依拓撲順序計算最短或最長路徑
+func dagPaths(g Graph, start int, longest bool) (map[int]float64, map[int]int, error) {
+	if !g.IsWeighted() {
+		return nil, nil, fmt.Errorf("DAG shortest paths require a weighted graph")
+	}
+	if _, err := g.GetNeighbors(start); err != nil {
+		return nil, nil, err
+	}
+	order, err := kahnOrder(g)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unreached := math.Inf(1)
+	better := func(a, b float64) bool { return a < b }
+	if longest {
+		unreached = math.Inf(-1)
+		better = func(a, b float64) bool { return a > b }
+	}
+
+	distances := make(map[int]float64, len(order))
+	predecessors := make(map[int]int)
+	for _, node := range order {
+		distances[node] = unreached
+	}
+	distances[start] = 0
+
+	for _, u := range order {
+		if distances[u] == unreached {
+			continue
+		}
+		neighbors, err := g.GetNeighbors(u)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, edge := range neighbors {
+			alt := distances[u] + edge.Weight
+			prev, ok := predecessors[edge.To]
+			if better(alt, distances[edge.To]) || ok && alt == distances[edge.To] && u < prev {
+				distances[edge.To] = alt
+				predecessors[edge.To] = u
+			}
+		}
+	}
+	return distances, predecessors, nil
+}
+
+// CPMTask 是關鍵路徑法中單一任務的排程資訊
+type CPMTask struct {
+	Duration       float64 // 任務工期
+	EarliestStart  float64 // 最早開始時間
+	EarliestFinish float64 // 最早完成時間
+	LatestStart    float64 // 不延誤整個專案的最晚開始時間
+	LatestFinish   float64 // 不延誤整個專案的最晚完成時間
+	Slack          float64 // 浮時（LatestStart - EarliestStart），為 0 表示位於關鍵路徑上
+}
+
+// CPMReport 是關鍵路徑分析的結果
+type CPMReport struct {
+	Tasks        map[int]CPMTask // 每個任務的排程資訊
+	Order        []int           // 任務的拓撲順序
+	CriticalPath []int           // 一條由浮時為 0 的任務構成、決定專案總工期的路徑
+	Duration     float64         // 專案總工期
+}
+
+// CriticalPath 對以節點表示任務、以邊表示依賴關係的有向無環圖進行 CPM/PERT 分析。
+// 邊 u -> v 表示 v 必須在 u 完成後才能開始；加權圖中邊的權重視為兩者之間的等待時間。
+//
+// Parameters:
+// - g: The task graph (must be a DAG).
+// - durations: The duration of each task; tasks missing from the map take no time.
+//
+// Returns:
+// - A CPMReport with earliest/latest start and finish times, slack and the critical path.
+// - A *CycleError with the cycle as witness if the dependencies are cyclic.
+//
+// Example:
+// report, _ := CriticalPath(g, map[int]float64{1: 3, 2: 2, 3: 4})
+// fmt.Println(report.Duration, report.CriticalPath)
+func CriticalPath(g Graph, durations map[int]float64) (*CPMReport, error) {
+	for node, d := range durations {
+		if d < 0 || math.IsNaN(d) {
+			return nil, fmt.Errorf("task %d has invalid duration %v", node, d)
+		}
+	}
+	order, err := TopologicalSort(g)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CPMReport{
+		Tasks: make(map[int]CPMTask, len(order)),
+		Order: order,
+	}
+
+	// 正向計算最早開始與完成時間
+	earliestStart := make(map[int]float64, len(order))
+	for _, u := range order {
+		finish := earliestStart[u] + durations[u]
+		report.Duration = math.Max(report.Duration, finish)
+		neighbors, err := g.GetNeighbors(u)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range neighbors {
+			earliestStart[edge.To] = math.Max(earliestStart[edge.To], finish+edge.Weight)
+		}
+	}
+
+	// 反向計算最晚完成與開始時間
+	latestFinish := make(map[int]float64, len(order))
+	for i := len(order) - 1; i >= 0; i-- {
+		u := order[i]
+		lf := report.Duration
+		neighbors, err := g.GetNeighbors(u)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range neighbors {
+			lf = math.Min(lf, latestFinish[edge.To]-durations[edge.To]-edge.Weight)
+		}
+		latestFinish[u] = lf
+	}
+
+	for _, u := range order {
+		es, lf := earliestStart[u], latestFinish[u]
+		report.Tasks[u] = CPMTask{
+			Duration:       durations[u],
+			EarliestStart:  es,
+			EarliestFinish: es + durations[u],
+			LatestStart:    lf - durations[u],
+			LatestFinish:   lf,
+			Slack:          lf - durations[u] - es,
+		}
+	}
+
+	report.CriticalPath, err = criticalChain(g, report)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// cpmEpsilon 是比較排程時間時容許的浮點誤差
+const cpmEpsilon = 1e-9
+
+// criticalChain 從最早開始時間為 0 的關鍵任務出發，沿著緊接的關鍵任務走到專案結束
+func criticalChain(g Graph, report *CPMReport) ([]int, error) {
+	if len(report.Order) == 0 {
+		return []int{}, nil
+	}
+
+	starts := []int{}
+	for _, u := range report.Order {
+		task := report.Tasks[u]
+		if math.Abs(task.Slack) < cpmEpsilon && task.EarliestStart < cpmEpsilon {
+			starts = append(starts, u)
+		}
+	}
+	if len(starts) == 0 {
+		return []int{}, nil // 只有在等待時間為負時才可能發生
+	}
+	sort.Ints(starts)
+
+	chain := []int{starts[0]}
+	for current := starts[0]; math.Abs(report.Tasks[current].EarliestFinish-report.Duration) >= cpmEpsilon; {
+		neighbors, err := g.GetNeighbors(current)
+		if err != nil {
+			return nil, err
+		}
+		next, found := 0, false
+		for _, edge := range neighbors {
+			task := report.Tasks[edge.To]
+			tight := math.Abs(report.Tasks[current].EarliestFinish+edge.Weight-task.EarliestStart) < cpmEpsilon
+			if tight && math.Abs(task.Slack) < cpmEpsilon && (!found || edge.To < next) {
+				next, found = edge.To, true
+			}
+		}
+		if !found {
+			break
+		}
+		chain = append(chain, next)
+		current = next
+	}
+	return chain, nil
+}
@@ -0,0 +1,147 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestTopologicalSort(t *testing.T) {
+	g := NewAdjacencyList(true, false)
+	for i := 1; i <= 5; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(5, 3, 0)
+	g.AddEdge(5, 1, 0)
+	g.AddEdge(1, 3, 0)
+	g.AddEdge(3, 2, 0)
+
+	order, err := TopologicalSort(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int{4, 5, 1, 3, 2}; !reflect.DeepEqual(order, want) {
+		t.Errorf("expected %v, got %v", want, order)
+	}
+
+	g.AddEdge(2, 5, 0)
+	_, err = TopologicalSort(g)
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("expected CycleError, got %v", err)
+	}
+	cycle := cycleErr.Cycle
+	if len(cycle) < 2 || cycle[0] != cycle[len(cycle)-1] {
+		t.Fatalf("cycle is not closed: %v", cycle)
+	}
+	for i := 0; i+1 < len(cycle); i++ {
+		if !g.HasEdge(cycle[i], cycle[i+1]) {
+			t.Errorf("cycle uses missing edge %d -> %d", cycle[i], cycle[i+1])
+		}
+	}
+}
+
+func TestDAGShortestAndLongestPaths(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	for i := 0; i <= 6; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 5)
+	g.AddEdge(0, 2, 3)
+	g.AddEdge(1, 3, 6)
+	g.AddEdge(1, 2, 2)
+	g.AddEdge(2, 4, 4)
+	g.AddEdge(2, 5, 2)
+	g.AddEdge(2, 3, 7)
+	g.AddEdge(3, 4, -1)
+	g.AddEdge(4, 5, -2)
+
+	shortest, pred, err := DAGShortestPaths(g, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[int]float64{0: 0, 1: 5, 2: 3, 3: 10, 4: 7, 5: 5, 6: math.Inf(1)}
+	if !reflect.DeepEqual(shortest, want) {
+		t.Errorf("shortest: expected %v, got %v", want, shortest)
+	}
+	if path, _ := PathTo(pred, 0, 4); !reflect.DeepEqual(path, []int{0, 2, 4}) {
+		t.Errorf("shortest path to 4: got %v", path)
+	}
+
+	// 0 -> 2 -> 5 與 0 -> 2 -> 4 -> 5 的長度相同，選擇 ID 較小的前驅節點
+	if pred[5] != 2 {
+		t.Errorf("shortest path to 5: expected predecessor 2, got %d", pred[5])
+	}
+
+	longest, pred, err := DAGLongestPaths(g, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = map[int]float64{0: 0, 1: 5, 2: 7, 3: 14, 4: 13, 5: 11, 6: math.Inf(-1)}
+	if !reflect.DeepEqual(longest, want) {
+		t.Errorf("longest: expected %v, got %v", want, longest)
+	}
+	if path, _ := PathTo(pred, 0, 5); !reflect.DeepEqual(path, []int{0, 1, 2, 3, 4, 5}) {
+		t.Errorf("longest path to 5: got %v", path)
+	}
+
+	g.AddEdge(5, 0, 1)
+	var cycleErr *CycleError
+	if _, _, err := DAGLongestPaths(g, 0); !errors.As(err, &cycleErr) {
+		t.Errorf("expected CycleError, got %v", err)
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	// 1 -> 2 -> 4, 1 -> 3 -> 4
+	g := NewAdjacencyList(true, false)
+	for i := 1; i <= 4; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(1, 2, 0)
+	g.AddEdge(1, 3, 0)
+	g.AddEdge(2, 4, 0)
+	g.AddEdge(3, 4, 0)
+
+	report, err := CriticalPath(g, map[int]float64{1: 3, 2: 2, 3: 5, 4: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Duration != 9 {
+		t.Errorf("expected duration 9, got %v", report.Duration)
+	}
+	if want := []int{1, 3, 4}; !reflect.DeepEqual(report.CriticalPath, want) {
+		t.Errorf("expected critical path %v, got %v", want, report.CriticalPath)
+	}
+	want := CPMTask{Duration: 2, EarliestStart: 3, EarliestFinish: 5, LatestStart: 6, LatestFinish: 8, Slack: 3}
+	if report.Tasks[2] != want {
+		t.Errorf("task 2: expected %+v, got %+v", want, report.Tasks[2])
+	}
+
+	// 加權邊表示等待時間，使 2 成為關鍵任務
+	w := NewAdjacencyList(true, true)
+	for i := 1; i <= 4; i++ {
+		w.AddNode(i)
+	}
+	w.AddEdge(1, 2, 0)
+	w.AddEdge(1, 3, 0)
+	w.AddEdge(2, 4, 4)
+	w.AddEdge(3, 4, 0)
+	report, err = CriticalPath(w, map[int]float64{1: 3, 2: 2, 3: 5, 4: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Duration != 10 || !reflect.DeepEqual(report.CriticalPath, []int{1, 2, 4}) {
+		t.Errorf("with lag: got duration %v and path %v", report.Duration, report.CriticalPath)
+	}
+
+	if _, err := CriticalPath(g, map[int]float64{1: -1}); err == nil {
+		t.Errorf("expected error for negative duration")
+	}
+	g.AddEdge(4, 1, 0)
+	var cycleErr *CycleError
+	if _, err := CriticalPath(g, nil); !errors.As(err, &cycleErr) {
+		t.Errorf("expected CycleError, got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrStopTraversal 可由 DFSVisitor 的回呼函數返回，用於提前結束遍歷。
+// DFSVisit 遇到此錯誤時會停止遍歷並返回目前為止的結果，不會視為失敗。
+var ErrStopTraversal = errors.New("stop traversal")
+
+// DFSVisitor 定義事件驅動深度優先搜索的回呼函數，未設定的回呼會被忽略。
+//
+// 邊的分類（以有向圖為例）：
+// - TreeEdge: 指向尚未發現的節點，成為 DFS 樹的一部分
+// - BackEdge: 指向仍在堆疊中的祖先節點，表示存在環
+// - ForwardEdge: 指向已完成的後代節點
+// - CrossEdge: 指向已完成且不是後代的節點
+//
+// 在無向圖中只會出現 TreeEdge 與 BackEdge，且每條邊只會被回報一次。
+type DFSVisitor struct {
+	DiscoverNode func(node int, time int) error  // 節點被發現時呼叫
+	FinishNode   func(node int, time int) error  // 節點的所有鄰居處理完成時呼叫
+	TreeEdge     func(from int, edge Edge) error // 樹邊
+	BackEdge     func(from int, edge Edge) error // 回邊
+	ForwardEdge  func(from int, edge Edge) error // 前向邊
+	CrossEdge    func(from int, edge Edge) error // 橫跨邊
+}
+
+// DFSResult 保存事件驅動深度優先搜索的結果
+type DFSResult struct {
+	Order     []int       // 節點的發現順序
+	Discovery map[int]int // 節點的發現時間
+	Finish    map[int]int // 節點的完成時間
+	Parent    map[int]int // DFS 森林中的父節點（根節點不包含在內）
+	Stopped   bool        // 是否被回呼函數以 ErrStopTraversal 提前終止
+}
+
+// DFSVisit performs an event-driven depth-first search and reports every node
+// and edge event to the visitor.
+//
+// Parameters:
+// - g: The graph to traverse (must implement the Graph interface).
+// - visitor: The hooks to call; nil hooks are skipped.
+// - roots: The nodes to start from, in order. If empty, every node of the graph
+// is used as a root in ascending order, producing a DFS forest.
+//
+// Returns:
+// - A DFSResult with discovery order, discovery/finish timestamps and the parent tree.
+// - An error returned by a hook or by the graph. ErrStopTraversal is not
+// reported as an error; the partial result is returned with Stopped set.
+//
+// Example:
+// result, _ := DFSVisit(g, DFSVisitor{
+//
+//	BackEdge: func(from int, edge Edge) error {
+//		fmt.Println("cycle through", from, edge.To)
+//		return ErrStopTraversal
+//	},
+//
+// })
+func DFSVisit(g Graph, visitor DFSVisitor, roots ...int) (*DFSResult, error) {
+	if len(roots) == 0 {
+		roots = g.GetNodes()
+		sort.Ints(roots)
+	}
+
+	result := &DFSResult{
+		Order:     []int{},
+		Discovery: make(map[int]int),
+		Finish:    make(map[int]int),
+		Parent:    make(map[int]int),
+	}
+	onStack := make(map[int]bool) // 仍在堆疊中的節點（灰色節點）
+	time := 0
+
+	// discover 標記節點為已發現，並建立其堆疊框架
+	discover := func(node int) (*dfsFrame, error) {
+		time++
+		result.Discovery[node] = time
+		result.Order = append(result.Order, node)
+		onStack[node] = true
+		if visitor.DiscoverNode != nil {
+			if err := visitor.DiscoverNode(node, time); err != nil {
+				return nil, err
+			}
+		}
+		neighbors, err := g.GetNeighbors(node)
+		if err != nil {
+			return nil, err
+		}
+		frame := &dfsFrame{node: node, neighbors: neighbors}
+		frame.parent, frame.hasParent = result.Parent[node]
+		return frame, nil
+	}
+
+	// visit 以顯式堆疊取代遞歸，避免在很深的圖上堆疊過度成長。
+	// 每個框架記錄下一條要處理的邊，因此訪問順序與遞歸版本完全相同。
+	visit := func(root int) error {
+		frame, err := discover(root)
+		if err != nil {
+			return err
+		}
+		stack := []*dfsFrame{frame}
+
+		for len(stack) > 0 {
+			frame := stack[len(stack)-1]
+			if frame.next < len(frame.neighbors) {
+				edge := frame.neighbors[frame.next]
+				frame.next++
+				if err := classifyEdge(g, visitor, result, onStack, frame.node, edge, frame.parent, frame.hasParent, &frame.skippedParent); err != nil {
+					return err
+				}
+				if _, discovered := result.Discovery[edge.To]; !discovered {
+					result.Parent[edge.To] = frame.node
+					if visitor.TreeEdge != nil {
+						if err := visitor.TreeEdge(frame.node, edge); err != nil {
+							return err
+						}
+					}
+					child, err := discover(edge.To)
+					if err != nil {
+						return err
+					}
+					stack = append(stack, child)
+				}
+				continue
+			}
+
+			// 所有鄰居處理完成，節點出堆疊
+			stack = stack[:len(stack)-1]
+			onStack[frame.node] = false
+			time++
+			result.Finish[frame.node] = time
+			if visitor.FinishNode != nil {
+				if err := visitor.FinishNode(frame.node, time); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, root := range roots {
+		if _, discovered := result.Discovery[root]; discovered {
+			continue
+		}
+		if err := visit(root); err != nil {
+			if errors.Is(err, ErrStopTraversal) {
+				result.Stopped = true
+				return result, nil
+			}
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// dfsFrame 是迭代式深度優先搜索中的一個堆疊框架
+type dfsFrame struct {
+	node          int    // 目前節點
+	neighbors     []Edge // 節點的鄰居列表
+	next          int    // 下一條要處理的邊的索引
+	parent        int    // DFS 樹中的父節點
+	hasParent     bool   // 是否有父節點（根節點沒有）
+	skippedParent bool   // 無向圖中是否已跳過通往父節點的反向樹邊
+}
+
+// classifyEdge 對指向已發現節點的邊進行分類並呼叫對應的回呼函數，
+// 指向尚未發現節點的樹邊由呼叫者處理
+func classifyEdge(g Graph, visitor DFSVisitor, result *DFSResult, onStack map[int]bool,
+	from int, edge Edge, parent int, hasParent bool, skippedParent *bool) error {
+	if _, discovered := result.Discovery[edge.To]; !discovered {
+		return nil
+	}
+
+	if !g.IsDirected() {
+		// 無向圖中，通往父節點的反向樹邊只跳過一次（允許平行邊被視為回邊）
+		if hasParent && edge.To == parent && !*skippedParent {
+			*skippedParent = true
+			return nil
+		}
+		// 指向已完成節點的邊已經在另一端以回邊的形式回報過
+		if !onStack[edge.To] {
+			return nil
+		}
+		if visitor.BackEdge != nil {
+			return visitor.BackEdge(from, edge)
+		}
+		return nil
+	}
+
+	switch {
+	case onStack[edge.To]:
+		if visitor.BackEdge != nil {
+			return visitor.BackEdge(from, edge)
+		}
+	case result.Discovery[from] < result.Discovery[edge.To]:
+		if visitor.ForwardEdge != nil {
+			return visitor.ForwardEdge(from, edge)
+		}
+	default:
+		if visitor.CrossEdge != nil {
+			return visitor.CrossEdge(from, edge)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,534 @@
+package graph
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+)
+
+// DynamicShortestPaths 維護從單一起點出發的最短路徑，並在 AdjacencyList 的邊權重改變時增量修復，
+// 不需要重新執行 Dijkstra：
+//   - 權重降低或新增邊時，只從受影響的節點開始向外鬆弛；
+//   - 權重增加或移除最短路徑樹上的邊時，只重新計算該邊下方子樹中的節點。
+//
+// 它透過 Subscribe 訂閱圖的變化，不再使用時請呼叫 Close。不支援並行存取。
+type DynamicShortestPaths struct {
+	graph        *AdjacencyList
+	source       int
+	distances    map[int]float64      // 只包含可到達的節點
+	predecessors map[int]int          // 最短路徑樹
+	children     map[int]map[int]bool // 最短路徑樹中每個節點的子節點
+	unsubscribe  func()
+	err          error // 修復過程中發生的錯誤，例如出現負權重
+}
+
+// NewDynamicShortestPaths 以 Dijkstra 計算初始的最短路徑，並開始追蹤圖的變化。
+//
+// Example:
+// sp, _ := NewDynamicShortestPaths(g, 0)
+// defer sp.Close()
+// g.UpdateEdgeWeight(3, 4, 12) // 路況改變
+// path, _ := sp.PathTo(9)
+func NewDynamicShortestPaths(g *AdjacencyList, source int) (*DynamicShortestPaths, error) {
+	if !g.IsWeighted() {
+		return nil, fmt.Errorf("Dijkstra requires a weighted graph")
+	}
+	if err := checkNonNegativeWeights(g); err != nil {
+		return nil, err
+	}
+	guard := SearchOptions{}.newGuard()
+	defer guard.release()
+	distances, predecessors, err := dijkstraSearch(g, source, source, false, guard)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &DynamicShortestPaths{
+		graph:        g,
+		source:       source,
+		distances:    distances,
+		predecessors: make(map[int]int, len(predecessors)),
+		children:     make(map[int]map[int]bool),
+	}
+	for v, u := range predecessors {
+		d.setPredecessor(v, u)
+	}
+	d.unsubscribe = g.Subscribe(d.onEdgeChange)
+	return d, nil
+}
+
+// Close 取消訂閱圖的變化，之後的查詢結果不再更新
+func (d *DynamicShortestPaths) Close() {
+	if d.unsubscribe != nil {
+		d.unsubscribe()
+		d.unsubscribe = nil
+	}
+}
+
+// Distance 返回從起點到 node 目前的最短距離，無法到達時返回 false
+func (d *DynamicShortestPaths) Distance(node int) (float64, bool, error) {
+	if d.err != nil {
+		return 0, false, d.err
+	}
+	dist, ok := d.distances[node]
+	return dist, ok, nil
+}
+
+// PathTo 返回從起點到 target 目前的最短路徑
+func (d *DynamicShortestPaths) PathTo(target int) (*Path, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	nodes, err := PathTo(d.predecessors, d.source, target)
+	if err != nil {
+		return nil, err
+	}
+	return newPath(d.graph, nodes)
+}
+
+// Distances 返回所有節點目前的最短距離，無法到達的節點為 math.Inf(1)
+func (d *DynamicShortestPaths) Distances() (map[int]float64, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	distances := make(map[int]float64, d.graph.NodeCount())
+	for _, node := range d.graph.GetNodes() {
+		distances[node] = math.Inf(1)
+	}
+	for node, dist := range d.distances {
+		distances[node] = dist
+	}
+	return distances, nil
+}
+
+// onEdgeChange 在圖的邊改變後修復最短路徑
+func (d *DynamicShortestPaths) onEdgeChange(change EdgeChange) {
+	if d.err != nil {
+		return
+	}
+	if change.NewWeight < 0 {
+		d.err = fmt.Errorf("%w: edge %d -> %d changed to %v", ErrNegativeWeight, change.From, change.To, change.NewWeight)
+		return
+	}
+	if !d.graph.HasNode(d.source) {
+		d.err = fmt.Errorf("source node %d was removed", d.source)
+		return
+	}
+
+	d.repair(change.From, change.To, change.NewWeight)
+	if !d.graph.IsDirected() {
+		d.repair(change.To, change.From, change.NewWeight)
+	}
+	if d.err == nil {
+		// 被移除的節點不再出現在結果中
+		for _, node := range []int{change.From, change.To} {
+			if !d.graph.HasNode(node) {
+				d.detach(node)
+			}
+		}
+	}
+}
+
+// repair 修復單一有向邊 u -> v 改變後的最短路徑
+func (d *DynamicShortestPaths) repair(u, v int, weight float64) {
+	pq := NewPriorityQueue()
+	if pred, ok := d.predecessors[v]; ok && pred == u {
+		// 樹邊變長或被移除：子樹中的節點需要重新計算
+		d.invalidateSubtree(v, pq)
+	} else if du, ok := d.distances[u]; ok && d.graph.HasNode(v) {
+		if dv, reached := d.distances[v]; !reached || du+weight < dv {
+			d.distances[v] = du + weight
+			d.setPredecessor(v, u)
+			heap.Push(pq, &Item{value: v, priority: du + weight})
+		}
+	}
+	if err := d.propagate(pq); err != nil {
+		d.err = err
+	}
+}
+
+// invalidateSubtree 移除 root 子樹中所有節點的距離，
+// 並以子樹外的入鄰居為每個節點計算新的候選距離
+func (d *DynamicShortestPaths) invalidateSubtree(root int, pq *PriorityQueue) {
+	affected := []int{root}
+	for i := 0; i < len(affected); i++ {
+		for child := range d.children[affected[i]] {
+			affected = append(affected, child)
+		}
+	}
+	for _, node := range affected {
+		d.detach(node)
+	}
+
+	for _, node := range affected {
+		if !d.graph.HasNode(node) {
+			continue
+		}
+		in, err := d.graph.GetInNeighbors(node)
+		if err != nil {
+			d.err = err
+			return
+		}
+		for _, edge := range in {
+			du, ok := d.distances[edge.To]
+			if !ok {
+				continue
+			}
+			if dv, reached := d.distances[node]; !reached || du+edge.Weight < dv {
+				d.distances[node] = du + edge.Weight
+				d.setPredecessor(node, edge.To)
+			}
+		}
+		if dist, ok := d.distances[node]; ok {
+			heap.Push(pq, &Item{value: node, priority: dist})
+		}
+	}
+}
+
+// propagate 從隊列中的節點開始執行 Dijkstra 鬆弛，直到沒有距離可以再降低
+func (d *DynamicShortestPaths) propagate(pq *PriorityQueue) error {
+	for pq.Len() > 0 {
+		item := heap.Pop(pq).(*Item)
+		u := item.value
+		if dist, ok := d.distances[u]; !ok || item.priority > dist {
+			continue // 過期的項目
+		}
+		neighbors, err := d.graph.GetNeighbors(u)
+		if err != nil {
+			return err
+		}
+		for _, edge := range neighbors {
+			alt := d.distances[u] + edge.Weight
+			if dv, ok := d.distances[edge.To]; !ok || alt < dv {
+				d.distances[edge.To] = alt
+				d.setPredecessor(edge.To, u)
+				heap.Push(pq, &Item{value: edge.To, priority: alt})
+			}
+		}
+	}
+	return nil
+}
+
+// setPredecessor 更新最短路徑樹中 v 的父節點
+func (d *DynamicShortestPaths) setPredecessor(v, u int) {
+	if old, ok := d.predecessors[v]; ok {
+		delete(d.children[old], v)
+	}
+	d.predecessors[v] = u
+	if d.children[u] == nil {
+		d.children[u] = make(map[int]bool)
+	}
+	d.children[u][v] = true
+}
+
+// detach 將節點從最短路徑樹中移除（不處理它的子節點）
+func (d *DynamicShortestPaths) detach(node int) {
+	if old, ok := d.predecessors[node]; ok {
+		delete(d.children[old], node)
+	}
+	delete(d.predecessors, node)
+	delete(d.distances, node)
+}
+
+// DStarLite 是為移動中的代理人規劃路徑的 D* Lite 算法：
+// 從目標往回搜索，代理人移動或邊權重改變後只修復受影響的部分，而不是重新規劃。
+// 它透過 Subscribe 收集圖的變化，並在下一次 Plan 時處理，不再使用時請呼叫 Close。
+//
+// 啟發式函數必須是一致的（h(a, c) <= c(a, b) + h(b, c)），例如 EuclideanHeuristic 或 Landmarks.Heuristic。
+//
+// Example:
+// planner, _ := NewDStarLite(g, start, goal, ManhattanHeuristic(coords))
+// defer planner.Close()
+//
+//	for planner.Start() != goal {
+//		path, err := planner.Plan()
+//		if err != nil {
+//			break
+//		}
+//		planner.Move(path.Nodes[1])
+//	}
+type DStarLite struct {
+	graph       *AdjacencyList
+	start, goal int
+	last        int // 上一次處理邊變化時代理人的位置
+	heuristic   Heuristic
+	km          float64           // 代理人移動所累積的鍵值修正量
+	g, rhs      map[int]dStarCost // 缺少的項目表示無窮大
+	keys        map[int][2]float64
+	pq          *PriorityQueue // priority 為第一鍵值，tiebreak 為第二鍵值
+	pending     []EdgeChange
+	unsubscribe func()
+}
+
+// NewDStarLite 建立從 start 到 goal 的 D* Lite 規劃器；heuristic 為 nil 時使用 0
+func NewDStarLite(g *AdjacencyList, start, goal int, heuristic Heuristic) (*DStarLite, error) {
+	if !g.IsWeighted() {
+		return nil, fmt.Errorf("D* Lite requires a weighted graph")
+	}
+	for _, node := range []int{start, goal} {
+		if !g.HasNode(node) {
+			return nil, fmt.Errorf("node %d does not exist in the graph", node)
+		}
+	}
+	if err := checkNonNegativeWeights(g); err != nil {
+		return nil, err
+	}
+	if heuristic == nil {
+		heuristic = func(node, goal int) float64 { return 0 }
+	}
+
+	d := &DStarLite{
+		graph:     g,
+		start:     start,
+		goal:      goal,
+		last:      start,
+		heuristic: heuristic,
+		g:         make(map[int]dStarCost),
+		rhs:       map[int]dStarCost{goal: {}},
+		keys:      make(map[int][2]float64),
+		pq:        NewPriorityQueue(),
+	}
+	d.push(goal)
+	d.unsubscribe = g.Subscribe(func(change EdgeChange) {
+		d.pending = append(d.pending, change)
+	})
+	return d, nil
+}
+
+// Close 取消訂閱圖的變化
+func (d *DStarLite) Close() {
+	if d.unsubscribe != nil {
+		d.unsubscribe()
+		d.unsubscribe = nil
+	}
+}
+
+// Start 返回代理人目前的位置
+func (d *DStarLite) Start() int {
+	return d.start
+}
+
+// Move 將代理人移動到 node，通常是上一次 Plan 所返回路徑的下一個節點
+func (d *DStarLite) Move(node int) error {
+	if !d.graph.HasNode(node) {
+		return fmt.Errorf("node %d does not exist in the graph", node)
+	}
+	d.start = node
+	return nil
+}
+
+// Plan 處理自上次規劃以來的邊變化，修復搜索結果，並返回從代理人目前位置到目標的最短路徑
+func (d *DStarLite) Plan() (*Path, error) {
+	if !d.graph.HasNode(d.start) || !d.graph.HasNode(d.goal) {
+		return nil, fmt.Errorf("start or goal node was removed from the graph")
+	}
+	if len(d.pending) > 0 {
+		d.km += d.heuristic(d.last, d.start)
+		d.last = d.start
+		for _, change := range d.pending {
+			if change.NewWeight < 0 {
+				return nil, fmt.Errorf("%w: edge %d -> %d changed to %v", ErrNegativeWeight, change.From, change.To, change.NewWeight)
+			}
+			// 邊 u -> v 改變只影響 u 的 rhs（無向圖則兩端都受影響）
+			if err := d.updateVertex(change.From); err != nil {
+				return nil, err
+			}
+			if !d.graph.IsDirected() {
+				if err := d.updateVertex(change.To); err != nil {
+					return nil, err
+				}
+			}
+		}
+		d.pending = nil
+	}
+
+	if err := d.computeShortestPath(); err != nil {
+		return nil, err
+	}
+	// 搜索結束時起點已一致，rhs(start) 即為最短距離
+	if math.IsInf(d.value(d.rhs, d.start).cost, 1) {
+		return nil, fmt.Errorf("%w: from %d to %d", ErrNoPath, d.start, d.goal)
+	}
+
+	nodes, err := d.extractPath()
+	if err != nil {
+		return nil, err
+	}
+	return newPath(d.graph, nodes)
+}
+
+// extractPath 從代理人的位置沿著緊的邊（c(u, v) + g(v) == rhs(u) 且 v 已一致）走到目標。
+// 沿緊的邊走的路徑成本恰好是 rhs(start)；以廣度優先搜索尋找，不會重複經過節點。
+func (d *DStarLite) extractPath() ([]int, error) {
+	predecessors := make(map[int]int)
+	visited := map[int]bool{d.start: true}
+	queue := []int{d.start}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		if u == d.goal {
+			return PathTo(predecessors, d.start, d.goal)
+		}
+		neighbors, err := d.graph.GetNeighbors(u)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range neighbors {
+			v := edge.To
+			if visited[v] || !d.consistent(v) || d.value(d.g, v).extend(edge.Weight) != d.value(d.rhs, u) {
+				continue
+			}
+			visited[v] = true
+			predecessors[v] = u
+			queue = append(queue, v)
+		}
+	}
+	return nil, fmt.Errorf("%w: from %d to %d", ErrNoPath, d.start, d.goal)
+}
+
+// computeShortestPath 處理隊列，直到代理人位置的距離估計正確。
+// 鍵值與起點相同的節點也會處理，使權重為 0 的邊上與起點同距離的節點都已一致，
+// 否則 extractPath 可能沿著過時的 g 值前進。
+func (d *DStarLite) computeShortestPath() error {
+	for {
+		u, topKey, ok := d.top()
+		startKey := d.calculateKey(d.start)
+		if !ok || keyLess(startKey, topKey) && d.consistent(d.start) {
+			return nil
+		}
+
+		if newKey := d.calculateKey(u); keyLess(topKey, newKey) {
+			d.push(u) // 鍵值過時，以新的鍵值重新排入
+			continue
+		}
+		delete(d.keys, u)
+		if !d.graph.HasNode(u) {
+			continue
+		}
+
+		predecessors, err := d.graph.GetInNeighbors(u)
+		if err != nil {
+			return err
+		}
+		if d.value(d.rhs, u).less(d.value(d.g, u)) {
+			d.g[u] = d.rhs[u] // 過度一致：確定距離
+		} else {
+			delete(d.g, u) // 不足一致：設為無窮大後重新計算
+			if err := d.updateVertex(u); err != nil {
+				return err
+			}
+		}
+		for _, edge := range predecessors {
+			if err := d.updateVertex(edge.To); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// updateVertex 重新計算節點的 rhs，並依是否一致決定是否放入隊列
+func (d *DStarLite) updateVertex(u int) error {
+	if !d.graph.HasNode(u) {
+		delete(d.g, u)
+		delete(d.rhs, u)
+		delete(d.keys, u)
+		return nil
+	}
+	if u != d.goal {
+		best, err := d.bestSuccessor(u)
+		if err != nil {
+			return err
+		}
+		if math.IsInf(best.cost, 1) {
+			delete(d.rhs, u)
+		} else {
+			d.rhs[u] = best
+		}
+	}
+	if d.value(d.g, u) != d.value(d.rhs, u) {
+		d.push(u)
+	} else {
+		delete(d.keys, u)
+	}
+	return nil
+}
+
+// bestSuccessor 返回所有後繼 v 中最小的 c(u, v) + g(v)
+func (d *DStarLite) bestSuccessor(u int) (dStarCost, error) {
+	neighbors, err := d.graph.GetNeighbors(u)
+	if err != nil {
+		return dStarCost{}, err
+	}
+	best := dStarCost{cost: math.Inf(1)}
+	for _, edge := range neighbors {
+		if cost := d.value(d.g, edge.To).extend(edge.Weight); cost.less(best) {
+			best = cost
+		}
+	}
+	return best, nil
+}
+
+// calculateKey 返回節點的鍵值 [min(g, rhs) + h(start, s) + km, min(g, rhs) 的邊數]。
+// 第一鍵值相同時以邊數決定順序，使前驅節點的鍵值嚴格大於後繼節點，即使邊的權重為 0。
+func (d *DStarLite) calculateKey(s int) [2]float64 {
+	m := d.value(d.g, s)
+	if rhs := d.value(d.rhs, s); rhs.less(m) {
+		m = rhs
+	}
+	return [2]float64{m.cost + d.heuristic(d.start, s) + d.km, float64(m.hops)}
+}
+
+// push 以目前的鍵值將節點放入隊列，舊的項目由 top 延遲刪除
+func (d *DStarLite) push(s int) {
+	key := d.calculateKey(s)
+	d.keys[s] = key
+	heap.Push(d.pq, &Item{value: s, priority: key[0], tiebreak: key[1]})
+}
+
+// top 返回隊列中鍵值最小的有效節點，不將它移出；處理節點時刪除 keys 中的項目即視為移出
+func (d *DStarLite) top() (int, [2]float64, bool) {
+	for d.pq.Len() > 0 {
+		item := (*d.pq)[0]
+		key, ok := d.keys[item.value]
+		if ok && key == [2]float64{item.priority, item.tiebreak} {
+			return item.value, key, true
+		}
+		heap.Pop(d.pq) // 已移出或鍵值已更新的過期項目
+	}
+	return 0, [2]float64{math.Inf(1), math.Inf(1)}, false
+}
+
+// value 返回表中的值，缺少的項目為無窮大
+func (d *DStarLite) value(table map[int]dStarCost, node int) dStarCost {
+	if v, ok := table[node]; ok {
+		return v
+	}
+	return dStarCost{cost: math.Inf(1)}
+}
+
+// consistent 返回節點的 g 是否等於 rhs
+func (d *DStarLite) consistent(node int) bool {
+	return d.value(d.g, node) == d.value(d.rhs, node)
+}
+
+// dStarCost 是 D* Lite 的距離估計：先比較權重總和，相同時比較邊數。
+// 以邊數作為次要成本使每條邊都嚴格增加成本，權重為 0 的環上的節點因此不會互相支撐過時的 g 值。
+type dStarCost struct {
+	cost float64 // 權重總和
+	hops int     // 邊數
+}
+
+// extend 返回在此距離之前再經過一條權重為 weight 的邊後的距離
+func (c dStarCost) extend(weight float64) dStarCost {
+	return dStarCost{cost: c.cost + weight, hops: c.hops + 1}
+}
+
+// less 以字典順序比較兩個距離
+func (c dStarCost) less(other dStarCost) bool {
+	return c.cost < other.cost || c.cost == other.cost && c.hops < other.hops
+}
+
+// keyLess 以字典順序比較兩個鍵值
+func keyLess(a, b [2]float64) bool {
+	return a[0] < b[0] || a[0] == b[0] && a[1] < b[1]
+}
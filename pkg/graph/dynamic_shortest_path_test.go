@@ -0,0 +1,258 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestUpdateEdgeWeightNotifies(t *testing.T) {
+	g := NewAdjacencyList(false, true)
+	g.AddNode(1)
+	g.AddNode(2)
+	changes := []EdgeChange{}
+	unsubscribe := g.Subscribe(func(change EdgeChange) { changes = append(changes, change) })
+
+	g.AddEdge(1, 2, 3)
+	if err := g.UpdateEdgeWeight(2, 1, 7); err != nil {
+		t.Fatalf("UpdateEdgeWeight failed: %v", err)
+	}
+	if neighbors, _ := g.GetNeighbors(1); neighbors[0].Weight != 7 {
+		t.Errorf("Expected the reverse edge to be updated, got %v", neighbors)
+	}
+	if err := g.UpdateEdgeWeight(1, 3, 1); err == nil {
+		t.Errorf("Expected error for missing edge")
+	}
+	unsubscribe()
+	g.UpdateEdgeWeight(1, 2, 9)
+
+	want := []EdgeChange{
+		{From: 1, To: 2, OldWeight: math.Inf(1), NewWeight: 3},
+		{From: 2, To: 1, OldWeight: 3, NewWeight: 7},
+	}
+	if len(changes) != len(want) || changes[0] != want[0] || changes[1] != want[1] {
+		t.Errorf("Expected %v, got %v", want, changes)
+	}
+}
+
+func TestDynamicShortestPaths(t *testing.T) {
+	for _, directed := range []bool{true, false} {
+		rng := rand.New(rand.NewSource(46))
+		g := NewAdjacencyList(directed, true)
+		const n = 80
+		for i := 0; i < n; i++ {
+			g.AddNode(i)
+		}
+		type pair struct{ from, to int }
+		edges := []pair{}
+		for i := 0; i < n*3; i++ {
+			from, to := rng.Intn(n), rng.Intn(n)
+			g.AddEdge(from, to, float64(1+rng.Intn(20)))
+			edges = append(edges, pair{from, to})
+		}
+
+		sp, err := NewDynamicShortestPaths(g, 0)
+		if err != nil {
+			t.Fatalf("NewDynamicShortestPaths failed: %v", err)
+		}
+		removed := map[int]bool{}
+		for step := 0; step < 300; step++ {
+			switch r := rng.Intn(10); {
+			case r < 7:
+				e := edges[rng.Intn(len(edges))]
+				if g.HasEdge(e.from, e.to) {
+					g.UpdateEdgeWeight(e.from, e.to, float64(rng.Intn(30)))
+				}
+			case r < 9:
+				from, to := rng.Intn(n), rng.Intn(n)
+				if g.AddEdge(from, to, float64(1+rng.Intn(20))) == nil {
+					edges = append(edges, pair{from, to})
+				}
+			default:
+				if node := 1 + rng.Intn(n-1); !removed[node] {
+					g.RemoveNode(node)
+					removed[node] = true
+				}
+			}
+
+			got, err := sp.Distances()
+			if err != nil {
+				t.Fatalf("step %d: Distances failed: %v", step, err)
+			}
+			want, _, derr := Dijkstra(g, 0)
+			if derr != nil {
+				t.Fatalf("dijkstra: %v", derr)
+			}
+			for node, dist := range want {
+				if got[node] != dist {
+					t.Fatalf("directed=%v step %d: distance of %d is %v, want %v", directed, step, node, got[node], dist)
+				}
+			}
+			if len(got) != len(want) {
+				t.Fatalf("step %d: got %d distances, want %d", step, len(got), len(want))
+			}
+		}
+
+		for target := 0; target < n; target++ {
+			path, err := sp.PathTo(target)
+			if dist, _, _ := sp.Distance(target); err == nil && path.Cost != dist {
+				t.Errorf("Path to %d costs %v, distance is %v", target, path.Cost, dist)
+			}
+		}
+
+		sp.Close()
+		g.AddNode(1000)
+		g.AddEdge(0, 1000, 1)
+		if _, ok, _ := sp.Distance(1000); ok {
+			t.Errorf("Expected no updates after Close")
+		}
+	}
+}
+
+func TestDynamicShortestPathsNegativeUpdate(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	g.AddNode(0)
+	g.AddNode(1)
+	g.AddEdge(0, 1, 2)
+	sp, _ := NewDynamicShortestPaths(g, 0)
+	g.UpdateEdgeWeight(0, 1, -1)
+	if _, err := sp.PathTo(1); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Expected ErrNegativeWeight, got %v", err)
+	}
+}
+
+func TestDStarLite(t *testing.T) {
+	g, coords := buildGrid(15, 15, nil)
+	start, goal := 0, 224
+	planner, err := NewDStarLite(g, start, goal, ManhattanHeuristic(coords))
+	if err != nil {
+		t.Fatalf("NewDStarLite failed: %v", err)
+	}
+	defer planner.Close()
+
+	rng := rand.New(rand.NewSource(3))
+	for steps := 0; planner.Start() != goal; steps++ {
+		if steps > 500 {
+			t.Fatalf("Agent did not reach the goal")
+		}
+		// 隨機讓一些邊變得難以通過，或恢復正常
+		for i := 0; i < 3; i++ {
+			node := rng.Intn(225)
+			neighbors, _ := g.GetNeighbors(node)
+			if len(neighbors) > 0 {
+				g.UpdateEdgeWeight(node, neighbors[rng.Intn(len(neighbors))].To, float64(1+rng.Intn(2)*20))
+			}
+		}
+
+		path, err := planner.Plan()
+		if err != nil {
+			t.Fatalf("Plan failed: %v", err)
+		}
+		expected, _ := ShortestPath(g, planner.Start(), goal)
+		if math.Abs(path.Cost-expected.Cost) > 1e-9 {
+			t.Fatalf("Step %d: planned cost %v, want %v", steps, path.Cost, expected.Cost)
+		}
+		if path.Nodes[0] != planner.Start() || path.Nodes[len(path.Nodes)-1] != goal {
+			t.Fatalf("Malformed path %v", path.Nodes)
+		}
+		planner.Move(path.Nodes[1])
+	}
+}
+
+func TestDStarLiteBlocked(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	for i := 0; i < 3; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	planner, _ := NewDStarLite(g, 0, 2, nil)
+	if path, err := planner.Plan(); err != nil || path.Cost != 2 {
+		t.Fatalf("Unexpected plan: %v, %v", path, err)
+	}
+	g.RemoveNode(1)
+	if _, err := planner.Plan(); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath after removing the only route, got %v", err)
+	}
+	g.AddEdge(0, 2, 5)
+	if path, err := planner.Plan(); err != nil || path.Cost != 5 {
+		t.Errorf("Expected the new edge to be used, got %v, %v", path, err)
+	}
+}
+
+func TestDStarLiteZeroWeightEdges(t *testing.T) {
+	// 權重為 0 的自環與 2 <-> 4 的環不應讓路徑繞圈
+	g := NewAdjacencyList(true, true)
+	for i := 1; i <= 4; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 2, 0)
+	g.AddEdge(2, 4, 0)
+	g.AddEdge(4, 2, 0)
+	g.AddEdge(2, 3, 1)
+	planner, _ := NewDStarLite(g, 1, 3, nil)
+	defer planner.Close()
+	path, err := planner.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if !reflect.DeepEqual(path.Nodes, []int{1, 2, 3}) || path.Cost != 2 {
+		t.Errorf("Expected path [1 2 3] with cost 2, got %v with cost %v", path.Nodes, path.Cost)
+	}
+}
+
+func TestDStarLiteZeroWeightUpdates(t *testing.T) {
+	// 隨機的無向圖中權重經常變為 0，每次規劃都與重新執行 Dijkstra 的結果比較
+	rng := rand.New(rand.NewSource(9))
+	for trial := 0; trial < 20; trial++ {
+		const n = 20
+		g := NewAdjacencyList(false, true)
+		for i := 0; i < n; i++ {
+			g.AddNode(i)
+		}
+		for i := 0; i < 3*n; i++ {
+			g.AddEdge(rng.Intn(n), rng.Intn(n), float64(rng.Intn(3)))
+		}
+		start, goal := rng.Intn(n), rng.Intn(n)
+		planner, err := NewDStarLite(g, start, goal, nil)
+		if err != nil {
+			t.Fatalf("NewDStarLite failed: %v", err)
+		}
+
+		for step := 0; step < 10; step++ {
+			for i := 0; i < 4; i++ {
+				node := rng.Intn(n)
+				neighbors, _ := g.GetNeighbors(node)
+				if len(neighbors) > 0 {
+					g.UpdateEdgeWeight(node, neighbors[rng.Intn(len(neighbors))].To, float64(rng.Intn(3)))
+				}
+			}
+
+			path, err := planner.Plan()
+			distances, _, _ := Dijkstra(g, planner.Start())
+			want, ok := distances[goal]
+			if !ok || math.IsInf(want, 1) {
+				if !errors.Is(err, ErrNoPath) {
+					t.Fatalf("Trial %d step %d: expected ErrNoPath, got %v", trial, step, err)
+				}
+				break
+			}
+			if err != nil {
+				t.Fatalf("Trial %d step %d: Plan failed: %v", trial, step, err)
+			}
+			if path.Cost != want {
+				t.Fatalf("Trial %d step %d: planned %v with cost %v, want %v", trial, step, path.Nodes, path.Cost, want)
+			}
+			if path.Nodes[0] != planner.Start() || path.Nodes[len(path.Nodes)-1] != goal {
+				t.Fatalf("Malformed path %v", path.Nodes)
+			}
+			if len(path.Nodes) > 1 {
+				planner.Move(path.Nodes[1])
+			}
+		}
+		planner.Close()
+	}
+}
@@ -0,0 +1,266 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// EulerianError 說明圖為何沒有歐拉路徑或歐拉迴路
+type EulerianError struct {
+	Circuit bool   // 是否在尋找迴路（否則為路徑）
+	Reason  string // 不滿足的條件
+	Nodes   []int  // 造成問題的節點，例如度數不平衡或不連通的節點（遞增排列）
+}
+
+func (e *EulerianError) Error() string {
+	kind := "path"
+	if e.Circuit {
+		kind = "circuit"
+	}
+	return fmt.Sprintf("no Eulerian %s: %s %v", kind, e.Reason, e.Nodes)
+}
+
+// HasEulerianPath 判斷圖是否有經過每條邊恰好一次的路徑。沒有邊的圖視為有（空路徑）。
+func HasEulerianPath(g Graph) bool {
+	_, err := checkEulerian(g, false)
+	return err == nil
+}
+
+// HasEulerianCircuit 判斷圖是否有經過每條邊恰好一次並回到起點的迴路
+func HasEulerianCircuit(g Graph) bool {
+	_, err := checkEulerian(g, true)
+	return err == nil
+}
+
+// EulerianPath 以 Hierholzer 算法建立經過每條邊恰好一次的路徑，支援有向圖、無向圖、平行邊與自環。
+// 孤立節點不影響結果。
+//
+// Returns:
+// - The nodes of the walk in order; consecutive nodes are joined by one unused edge.
+// - An *EulerianError explaining which degree or connectivity condition fails.
+//
+// Example:
+// nodes, err := EulerianPath(g)
+// var eulerErr *EulerianError
+//
+//	if errors.As(err, &eulerErr) {
+//		fmt.Println(eulerErr.Reason, eulerErr.Nodes)
+//	}
+func EulerianPath(g Graph) ([]int, error) {
+	return eulerianWalk(g, false)
+}
+
+// EulerianCircuit 以 Hierholzer 算法建立經過每條邊恰好一次並回到起點的迴路，
+// 返回的節點序列首尾相同。
+func EulerianCircuit(g Graph) ([]int, error) {
+	return eulerianWalk(g, true)
+}
+
+func eulerianWalk(g Graph, circuit bool) ([]int, error) {
+	m, err := checkEulerian(g, circuit)
+	if err != nil {
+		return nil, err
+	}
+	if len(m.edges) == 0 {
+		return []int{}, nil
+	}
+	nodes, _ := m.hierholzer(m.start)
+	return nodes, nil
+}
+
+// multigraph 以邊編號表示的多重圖，用於 Hierholzer 算法標記已使用的邊
+type multigraph struct {
+	directed bool
+	edges    []multiEdge       // 所有邊，無向邊只出現一次
+	adj      map[int][]edgeRef // 每個節點可以經過的邊
+	start    int               // 建議的起點
+}
+
+// multiEdge 是多重圖中的一條邊
+type multiEdge struct {
+	from, to int
+	weight   float64
+}
+
+// edgeRef 是從某節點出發經過的邊
+type edgeRef struct {
+	id int // 在 multigraph.edges 中的索引
+	to int // 經過此邊後到達的節點
+}
+
+// newMultigraph 將圖轉換為多重圖；無向圖中每條邊（包括自環）只保留一份
+func newMultigraph(g Graph) (*multigraph, error) {
+	m := &multigraph{directed: g.IsDirected(), adj: make(map[int][]edgeRef)}
+	nodes := g.GetNodes()
+	sort.Ints(nodes)
+	for _, u := range nodes {
+		neighbors, err := g.GetNeighbors(u)
+		if err != nil {
+			return nil, err
+		}
+		loops := 0
+		for _, edge := range neighbors {
+			switch {
+			case m.directed:
+				m.addEdge(u, edge.To, edge.Weight)
+			case edge.To == u:
+				// 無向圖的自環在鄰居列表中出現兩次
+				if loops++; loops%2 == 0 {
+					m.addEdge(u, u, edge.Weight)
+				}
+			case u < edge.To:
+				m.addEdge(u, edge.To, edge.Weight)
+			}
+		}
+	}
+	return m, nil
+}
+
+// addEdge 加入一條邊；無向邊同時可以從兩端經過
+func (m *multigraph) addEdge(from, to int, weight float64) {
+	id := len(m.edges)
+	m.edges = append(m.edges, multiEdge{from: from, to: to, weight: weight})
+	m.adj[from] = append(m.adj[from], edgeRef{id: id, to: to})
+	if !m.directed {
+		m.adj[to] = append(m.adj[to], edgeRef{id: id, to: from})
+	}
+}
+
+// checkEulerian 檢查度數與連通條件，並選擇起點
+func checkEulerian(g Graph, circuit bool) (*multigraph, error) {
+	m, err := newMultigraph(g)
+	if err != nil {
+		return nil, err
+	}
+	if len(m.edges) == 0 {
+		return m, nil
+	}
+
+	// 度數條件：有向圖比較出度與入度，無向圖計算奇數度節點
+	balance := make(map[int]int)
+	for _, e := range m.edges {
+		if m.directed {
+			balance[e.from]++
+			balance[e.to]--
+		} else if e.from != e.to {
+			balance[e.from] ^= 1
+			balance[e.to] ^= 1
+		}
+	}
+	var starts, ends, unbalanced []int
+	for node, b := range balance {
+		switch {
+		case b == 0:
+		case !m.directed || b == 1:
+			starts = append(starts, node)
+		case b == -1:
+			ends = append(ends, node)
+		default:
+			unbalanced = append(unbalanced, node)
+		}
+	}
+	sort.Ints(starts)
+	sort.Ints(ends)
+	sort.Ints(unbalanced)
+
+	fail := func(reason string, nodes []int) error {
+		return &EulerianError{Circuit: circuit, Reason: reason, Nodes: nodes}
+	}
+	switch {
+	case !m.directed && circuit && len(starts) > 0:
+		return nil, fail("nodes with odd degree", starts)
+	case !m.directed && len(starts) > 2:
+		return nil, fail("more than two nodes with odd degree", starts)
+	case m.directed && len(unbalanced) > 0:
+		return nil, fail("nodes whose out-degree and in-degree differ by more than one", unbalanced)
+	case m.directed && circuit && len(starts)+len(ends) > 0:
+		return nil, fail("nodes whose out-degree differs from in-degree", sortedUnion(starts, ends))
+	case m.directed && (len(starts) > 1 || len(ends) > 1 || len(starts) != len(ends)):
+		return nil, fail("more than one start (out-degree = in-degree + 1) or end node", sortedUnion(starts, ends))
+	}
+
+	// 連通條件：所有有邊的節點必須在同一個（弱）連通分量中
+	if len(starts) > 0 {
+		m.start = starts[0]
+	} else {
+		m.start = m.edges[0].from
+		for node := range m.adj {
+			m.start = min(m.start, node)
+		}
+	}
+	if unreached := m.unreachable(); len(unreached) > 0 {
+		return nil, fail("edges are not all in one connected component; unreachable nodes", unreached)
+	}
+	return m, nil
+}
+
+// unreachable 返回忽略方向時無法從起點到達、但有邊的節點
+func (m *multigraph) unreachable() []int {
+	undirected := make(map[int][]int)
+	for _, e := range m.edges {
+		undirected[e.from] = append(undirected[e.from], e.to)
+		undirected[e.to] = append(undirected[e.to], e.from)
+	}
+	visited := map[int]bool{m.start: true}
+	stack := []int{m.start}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, v := range undirected[u] {
+			if !visited[v] {
+				visited[v] = true
+				stack = append(stack, v)
+			}
+		}
+	}
+	unreached := []int{}
+	for node := range undirected {
+		if !visited[node] {
+			unreached = append(unreached, node)
+		}
+	}
+	sort.Ints(unreached)
+	return unreached
+}
+
+// hierholzer 從 start 出發走遍所有可到達的邊，返回節點序列與依序經過的邊編號
+func (m *multigraph) hierholzer(start int) ([]int, []int) {
+	used := make([]bool, len(m.edges))
+	next := make(map[int]int) // 每個節點下一條待檢查的邊
+	stack := []int{start}
+	via := []int{} // via[i] 是從 stack[i] 走到 stack[i+1] 的邊
+	nodes, edges := []int{}, []int{}
+
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		refs := m.adj[u]
+		for next[u] < len(refs) && used[refs[next[u]].id] {
+			next[u]++
+		}
+		if next[u] < len(refs) {
+			ref := refs[next[u]]
+			used[ref.id] = true
+			stack = append(stack, ref.to)
+			via = append(via, ref.id)
+			continue
+		}
+		// 沒有未使用的邊：回溯並將節點加入結果
+		stack = stack[:len(stack)-1]
+		nodes = append(nodes, u)
+		if len(via) > 0 {
+			edges = append(edges, via[len(via)-1])
+			via = via[:len(via)-1]
+		}
+	}
+	slices.Reverse(nodes)
+	slices.Reverse(edges)
+	return nodes, edges
+}
+
+// sortedUnion 合併兩個已排序的切片
+func sortedUnion(a, b []int) []int {
+	result := append(append([]int{}, a...), b...)
+	sort.Ints(result)
+	return result
+}
@@ -0,0 +1,192 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// checkEulerianWalk 驗證 walk 恰好經過圖中每條邊一次
+func checkEulerianWalk(t *testing.T, g *AdjacencyList, walk []int) {
+	t.Helper()
+	remaining := make(map[[2]int]int)
+	total := 0
+	for _, u := range g.GetNodes() {
+		neighbors, _ := g.GetNeighbors(u)
+		for _, edge := range neighbors {
+			remaining[[2]int{u, edge.To}]++
+			total++
+		}
+	}
+	if !g.IsDirected() {
+		total /= 2
+	}
+	if len(walk) != total+1 {
+		t.Fatalf("walk %v has %d edges, graph has %d", walk, len(walk)-1, total)
+	}
+	for i := 0; i+1 < len(walk); i++ {
+		u, v := walk[i], walk[i+1]
+		if remaining[[2]int{u, v}] == 0 {
+			t.Fatalf("walk %v uses edge %d -> %d too often", walk, u, v)
+		}
+		remaining[[2]int{u, v}]--
+		if !g.IsDirected() {
+			remaining[[2]int{v, u}]--
+		}
+	}
+}
+
+func TestEulerianCircuitUndirected(t *testing.T) {
+	// 兩個共用節點 3 的三角形，加上平行邊 1-2 與自環 4
+	g := NewAdjacencyList(false, false)
+	for i := 1; i <= 6; i++ {
+		g.AddNode(i)
+	}
+	edges := [][2]int{{1, 2}, {2, 3}, {3, 1}, {3, 4}, {4, 5}, {5, 3}, {1, 2}, {2, 1}, {4, 4}}
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1], 0)
+	}
+
+	if !HasEulerianCircuit(g) || !HasEulerianPath(g) {
+		t.Fatal("expected an Eulerian circuit")
+	}
+	walk, err := EulerianCircuit(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if walk[0] != 1 || walk[len(walk)-1] != 1 {
+		t.Errorf("circuit should start and end at node 1, got %v", walk)
+	}
+	checkEulerianWalk(t, g, walk)
+}
+
+func TestEulerianPathUndirected(t *testing.T) {
+	// 柯尼斯堡七橋：四個節點的度數皆為奇數
+	bridges := [][2]int{{0, 1}, {0, 1}, {0, 2}, {0, 2}, {1, 3}, {2, 3}, {0, 3}}
+	build := func(bridges [][2]int) *AdjacencyList {
+		g := NewAdjacencyList(false, false)
+		for i := 0; i < 4; i++ {
+			g.AddNode(i)
+		}
+		for _, e := range bridges {
+			g.AddEdge(e[0], e[1], 0)
+		}
+		return g
+	}
+	g := build(bridges)
+	if HasEulerianPath(g) {
+		t.Fatal("Königsberg bridges should have no Eulerian path")
+	}
+	_, err := EulerianPath(g)
+	var eulerErr *EulerianError
+	if !errors.As(err, &eulerErr) {
+		t.Fatalf("expected EulerianError, got %v", err)
+	}
+	if want := []int{0, 1, 2, 3}; !reflect.DeepEqual(eulerErr.Nodes, want) {
+		t.Errorf("expected odd nodes %v, got %v", want, eulerErr.Nodes)
+	}
+
+	// 拆掉一座橋後只剩兩個奇數度節點，路徑必須從較小者出發
+	g = build(bridges[:len(bridges)-1])
+	if HasEulerianCircuit(g) {
+		t.Error("graph with odd nodes should have no circuit")
+	}
+	walk, err := EulerianPath(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if walk[0] != 1 || walk[len(walk)-1] != 2 {
+		t.Errorf("path should run from 1 to 2, got %v", walk)
+	}
+	checkEulerianWalk(t, g, walk)
+}
+
+func TestEulerianPathDirected(t *testing.T) {
+	// DNA 組裝：3-mer 的 de Bruijn 圖，節點為 2-mer
+	kmers := []string{"ATG", "TGG", "GGC", "GCA", "CAT", "ATC"}
+	ids := map[string]int{}
+	labels := []string{}
+	g := NewAdjacencyList(true, false)
+	id := func(s string) int {
+		if n, ok := ids[s]; ok {
+			return n
+		}
+		ids[s] = len(labels)
+		labels = append(labels, s)
+		g.AddNode(ids[s])
+		return ids[s]
+	}
+	for _, kmer := range kmers {
+		g.AddEdge(id(kmer[:2]), id(kmer[1:]), 0)
+	}
+
+	walk, err := EulerianPath(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkEulerianWalk(t, g, walk)
+	genome := labels[walk[0]]
+	for _, node := range walk[1:] {
+		genome += labels[node][1:]
+	}
+	if genome != "ATGGCATC" {
+		t.Errorf("expected genome ATGGCATC, got %s", genome)
+	}
+	if HasEulerianCircuit(g) {
+		t.Error("expected no circuit")
+	}
+	_, err = EulerianCircuit(g)
+	var eulerErr *EulerianError
+	if !errors.As(err, &eulerErr) || !eulerErr.Circuit {
+		t.Fatalf("expected circuit EulerianError, got %v", err)
+	}
+	if want := []int{ids["AT"], ids["TC"]}; !reflect.DeepEqual(eulerErr.Nodes, want) {
+		t.Errorf("expected unbalanced nodes %v, got %v", want, eulerErr.Nodes)
+	}
+
+	// 補上 TC -> AT 後形成迴路
+	g.AddEdge(ids["TC"], ids["AT"], 0)
+	walk, err = EulerianCircuit(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkEulerianWalk(t, g, walk)
+}
+
+func TestEulerianDiagnostics(t *testing.T) {
+	// 有向圖：節點 1 的出度比入度多 2
+	g := NewAdjacencyList(true, false)
+	for i := 1; i <= 3; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(1, 2, 0)
+	g.AddEdge(1, 3, 0)
+	_, err := EulerianPath(g)
+	var eulerErr *EulerianError
+	if !errors.As(err, &eulerErr) || !reflect.DeepEqual(eulerErr.Nodes, []int{1}) {
+		t.Errorf("expected node 1 to be reported, got %v", err)
+	}
+
+	// 兩個不相連的迴路，孤立節點 9 不影響結果
+	g = NewAdjacencyList(true, false)
+	for _, n := range []int{1, 2, 3, 4, 9} {
+		g.AddNode(n)
+	}
+	g.AddEdge(1, 2, 0)
+	g.AddEdge(2, 1, 0)
+	g.AddEdge(3, 4, 0)
+	g.AddEdge(4, 3, 0)
+	if HasEulerianPath(g) {
+		t.Error("disconnected edges should have no Eulerian path")
+	}
+	_, err = EulerianCircuit(g)
+	if !errors.As(err, &eulerErr) || !reflect.DeepEqual(eulerErr.Nodes, []int{3, 4}) {
+		t.Errorf("expected unreachable nodes [3 4], got %v", err)
+	}
+
+	// 沒有邊的圖有空的歐拉路徑
+	walk, err := EulerianCircuit(NewAdjacencyList(false, false))
+	if err != nil || len(walk) != 0 {
+		t.Errorf("expected empty walk, got %v, %v", walk, err)
+	}
+}
@@ -1,5 +1,10 @@
 package graph
 
+import "errors"
+
+// ErrNoPath 表示兩個節點之間不存在路徑
+var ErrNoPath = errors.New("no path")
+
 // Graph defines the core graph interface
 type Graph interface {
 	AddNode(id int) error                       // 添加節點
@@ -18,3 +23,10 @@ type Edge struct {
 	To     int     // 終點節點
 	Weight float64 // 邊的權重
 }
+
+// InNeighborGraph 是可以直接查詢入邊的圖。
+// 反向搜索（例如雙向搜索）會優先使用此介面，否則需要先掃描整張圖建立反向鄰接表。
+type InNeighborGraph interface {
+	Graph
+	GetInNeighbors(node int) ([]Edge, error) // 返回指向 node 的邊，Edge.To 為邊的起點
+}
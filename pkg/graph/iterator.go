@@ -1,5 +1,11 @@
 package graph
 
+import (
+	"container/heap"
+	"fmt"
+	"math"
+)
+
 // Iterator 定義圖遍歷的基本接口
 type Iterator interface {
 	// HasNext 返回是否還有下一個節點
@@ -46,10 +52,162 @@ type RandomWalkIterator struct {
 }
 
 // ClosestFirstIterator 最近優先迭代器(用於Dijkstra)
+//
+// 按照與起點的距離由近到遠依序產生節點，每次 Next 只展開一個節點，
+// 因此可以在找到足夠的節點後提前停止，不必對整張圖執行 Dijkstra。
+// 若設定了半徑，距離超過半徑的節點不會被產生。
 type ClosestFirstIterator struct {
-	graph         Graph
-	start         int
-	distances     map[int]float64
-	visited       map[int]bool
-	pq            *PriorityQueue
-} 
\ No newline at end of file
+	graph        Graph
+	start        int
+	radius       float64         // 搜索半徑，math.Inf(1) 表示不限制
+	distances    map[int]float64 // 目前已知的最短距離
+	predecessors map[int]int     // 最短路徑樹中的前驅節點
+	visited      map[int]bool    // 已產生（距離已確定）的節點
+	pq           *PriorityQueue
+}
+
+// NewClosestFirstIterator creates an iterator that yields nodes in increasing
+// distance from start. Pass math.Inf(1) as radius to traverse every reachable node.
+//
+// Example:
+// it, _ := NewClosestFirstIterator(g, 0, 5)
+//
+//	for it.HasNext() {
+//		node, _ := it.Next()
+//		dist, _ := it.Distance(node)
+//		fmt.Println(node, dist)
+//	}
+func NewClosestFirstIterator(g Graph, start int, radius float64) (*ClosestFirstIterator, error) {
+	if !g.IsWeighted() {
+		return nil, fmt.Errorf("ClosestFirstIterator requires a weighted graph")
+	}
+	if radius < 0 || math.IsNaN(radius) {
+		return nil, fmt.Errorf("invalid radius %v", radius)
+	}
+	if _, err := g.GetNeighbors(start); err != nil {
+		return nil, err
+	}
+	if err := precheckNonNegativeWeights(g); err != nil {
+		return nil, err
+	}
+
+	it := &ClosestFirstIterator{
+		graph:  g,
+		start:  start,
+		radius: radius,
+	}
+	it.Reset()
+	return it, nil
+}
+
+// HasNext 返回是否還有下一個節點
+func (it *ClosestFirstIterator) HasNext() bool {
+	it.skipStale()
+	return it.pq.Len() > 0
+}
+
+// Next 返回下一個距離最近的節點，並展開其鄰居
+func (it *ClosestFirstIterator) Next() (int, error) {
+	it.skipStale()
+	if it.pq.Len() == 0 {
+		return 0, fmt.Errorf("no more nodes within radius %v of node %d", it.radius, it.start)
+	}
+
+	u := heap.Pop(it.pq).(*Item).value
+	it.visited[u] = true
+
+	neighbors, err := it.graph.GetNeighbors(u)
+	if err != nil {
+		return 0, err
+	}
+	for _, edge := range neighbors {
+		if err := checkEdgeWeight(u, edge); err != nil {
+			return 0, err
+		}
+		v := edge.To
+		if it.visited[v] {
+			continue
+		}
+		alt := it.distances[u] + edge.Weight
+		if alt > it.radius {
+			continue // 超出半徑的節點不需要加入隊列
+		}
+		if d, ok := it.distances[v]; !ok || alt < d {
+			it.distances[v] = alt
+			it.predecessors[v] = u
+			heap.Push(it.pq, &Item{value: v, priority: alt})
+		}
+	}
+	return u, nil
+}
+
+// NextWithDistance 返回下一個節點、其最短距離以及前驅節點。
+// 起點沒有前驅節點，此時返回的前驅節點為起點本身。
+func (it *ClosestFirstIterator) NextWithDistance() (node int, distance float64, predecessor int, err error) {
+	node, err = it.Next()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	predecessor, ok := it.predecessors[node]
+	if !ok {
+		predecessor = node
+	}
+	return node, it.distances[node], predecessor, nil
+}
+
+// Distance 返回已產生節點的最短距離，若節點尚未被產生則返回 false
+func (it *ClosestFirstIterator) Distance(node int) (float64, bool) {
+	if !it.visited[node] {
+		return math.Inf(1), false
+	}
+	return it.distances[node], true
+}
+
+// Predecessor 返回已產生節點在最短路徑樹中的前驅節點，起點或尚未產生的節點返回 false
+func (it *ClosestFirstIterator) Predecessor(node int) (int, bool) {
+	if !it.visited[node] {
+		return 0, false
+	}
+	pred, ok := it.predecessors[node]
+	return pred, ok
+}
+
+// Reset 重置迭代器
+func (it *ClosestFirstIterator) Reset() {
+	it.distances = map[int]float64{it.start: 0}
+	it.predecessors = make(map[int]int)
+	it.visited = make(map[int]bool)
+	it.pq = NewPriorityQueue()
+	heap.Push(it.pq, &Item{value: it.start, priority: 0})
+}
+
+// skipStale 移除隊列頂端已訪問過或過期的項目
+func (it *ClosestFirstIterator) skipStale() {
+	for it.pq.Len() > 0 {
+		top := (*it.pq)[0]
+		if !it.visited[top.value] && top.priority <= it.distances[top.value] {
+			return
+		}
+		heap.Pop(it.pq)
+	}
+}
+
+// NodesWithinRadius 返回與起點距離不超過 radius 的所有節點（依距離遞增排序）及其距離
+func NodesWithinRadius(g Graph, start int, radius float64) ([]int, map[int]float64, error) {
+	it, err := NewClosestFirstIterator(g, start, radius)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nodes := []int{}
+	distances := make(map[int]float64)
+	for it.HasNext() {
+		node, dist, _, err := it.NextWithDistance()
+		if err != nil {
+			return nil, nil, err
+		}
+		nodes = append(nodes, node)
+		distances[node] = dist
+	}
+	return nodes, distances, nil
+}
@@ -0,0 +1,62 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClosestFirstIterator(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	for _, node := range []int{10, 20, 30, 40, 50} {
+		g.AddNode(node)
+	}
+	g.AddEdge(10, 20, 4)
+	g.AddEdge(10, 30, 1)
+	g.AddEdge(30, 20, 2)
+	g.AddEdge(20, 40, 5)
+	g.AddEdge(40, 50, 1)
+
+	it, err := NewClosestFirstIterator(g, 10, math.Inf(1))
+	if err != nil {
+		t.Fatalf("NewClosestFirstIterator failed: %v", err)
+	}
+
+	expected := []struct {
+		node int
+		dist float64
+		pred int
+	}{
+		{10, 0, 10},
+		{30, 1, 10},
+		{20, 3, 30},
+		{40, 8, 20},
+		{50, 9, 40},
+	}
+	for _, want := range expected {
+		if !it.HasNext() {
+			t.Fatalf("Iterator ended early, expected node %d", want.node)
+		}
+		node, dist, pred, err := it.NextWithDistance()
+		if err != nil {
+			t.Fatalf("NextWithDistance failed: %v", err)
+		}
+		if node != want.node || dist != want.dist || pred != want.pred {
+			t.Errorf("Got (%d, %f, %d), want (%d, %f, %d)", node, dist, pred, want.node, want.dist, want.pred)
+		}
+	}
+	if it.HasNext() {
+		t.Errorf("Expected iterator to be exhausted")
+	}
+
+	// 測試半徑限制
+	nodes, distances, err := NodesWithinRadius(g, 10, 3)
+	if err != nil {
+		t.Fatalf("NodesWithinRadius failed: %v", err)
+	}
+	if len(nodes) != 3 || nodes[0] != 10 || nodes[1] != 30 || nodes[2] != 20 {
+		t.Errorf("Unexpected nodes within radius: %v", nodes)
+	}
+	if distances[20] != 3 {
+		t.Errorf("Incorrect distance for node 20: got %f, want 3", distances[20])
+	}
+}
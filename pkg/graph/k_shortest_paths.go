@@ -0,0 +1,214 @@
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// KShortestPaths 以 Yen 算法計算從 start 到 target 成本最小的 k 條簡單路徑（不含重複節點），
+// 依成本遞增排序。若不足 k 條路徑，則返回所有存在的路徑。
+//
+// Example:
+// paths, _ := KShortestPaths(g, 1, 6, 3)
+//
+//	for _, p := range paths {
+//		fmt.Println(p.Nodes, p.Cost)
+//	}
+func KShortestPaths(g Graph, start, target, k int) ([]*Path, error) {
+	it, err := NewKShortestPathIterator(g, start, target)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []*Path{}
+	for len(paths) < k && it.HasNext() {
+		path, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	if it.err != nil {
+		return nil, it.err
+	}
+	if len(paths) == 0 && k > 0 {
+		return nil, fmt.Errorf("%w: from %d to %d", ErrNoPath, start, target)
+	}
+	return paths, nil
+}
+
+// KShortestPathIterator 依成本遞增順序逐條產生從起點到終點的簡單路徑（Yen 算法），
+// 只有在呼叫 Next 時才計算下一條路徑。
+type KShortestPathIterator struct {
+	graph      Graph
+	start      int
+	target     int
+	found      []*Path         // 已產生的路徑
+	candidates []*Path         // 候選路徑，由 pq 以成本排序
+	pq         *PriorityQueue  // 候選路徑的優先隊列，Item.value 為 candidates 的索引
+	seen       map[string]bool // 已產生或已成為候選的路徑
+	pending    *Path           // 已計算但尚未返回的下一條路徑
+	started    bool            // 是否已計算過第一條路徑
+	err        error           // 計算過程中發生的錯誤
+}
+
+// NewKShortestPathIterator 建立從 start 到 target 的 k 最短路徑迭代器
+func NewKShortestPathIterator(g Graph, start, target int) (*KShortestPathIterator, error) {
+	if !g.IsWeighted() {
+		return nil, fmt.Errorf("KShortestPaths requires a weighted graph")
+	}
+	if _, err := g.GetNeighbors(start); err != nil {
+		return nil, err
+	}
+	if _, err := g.GetNeighbors(target); err != nil {
+		return nil, err
+	}
+	if err := precheckNonNegativeWeights(g); err != nil {
+		return nil, err
+	}
+
+	return &KShortestPathIterator{
+		graph:  g,
+		start:  start,
+		target: target,
+		pq:     NewPriorityQueue(),
+		seen:   make(map[string]bool),
+	}, nil
+}
+
+// HasNext 返回是否還有下一條路徑
+func (it *KShortestPathIterator) HasNext() bool {
+	if it.pending == nil && it.err == nil {
+		it.pending, it.err = it.advance()
+	}
+	return it.pending != nil
+}
+
+// Next 返回下一條成本最小的路徑，沒有更多路徑時返回包裝了 ErrNoPath 的錯誤
+func (it *KShortestPathIterator) Next() (*Path, error) {
+	if !it.HasNext() {
+		if it.err != nil {
+			return nil, it.err
+		}
+		return nil, fmt.Errorf("%w: no more paths from %d to %d", ErrNoPath, it.start, it.target)
+	}
+	path := it.pending
+	it.pending = nil
+	it.found = append(it.found, path)
+	return path, nil
+}
+
+// advance 計算下一條路徑，沒有更多路徑時返回 nil
+func (it *KShortestPathIterator) advance() (*Path, error) {
+	guard := SearchOptions{}.newGuard()
+	defer guard.release()
+
+	if !it.started {
+		it.started = true
+		path, err := dijkstraPath(it.graph, it.start, it.target, guard)
+		if err != nil {
+			return nil, ignoreNoPath(err)
+		}
+		it.seen[pathKey(path.Nodes)] = true
+		return path, nil
+	}
+	if len(it.found) == 0 {
+		return nil, nil
+	}
+
+	// 以上一條路徑上的每個節點作為偏離點，產生新的候選路徑
+	last := it.found[len(it.found)-1]
+	for i := 0; i < len(last.Nodes)-1; i++ {
+		spur := last.Nodes[i]
+		root := last.Nodes[:i+1]
+
+		masked := &maskedGraph{
+			Graph:        it.graph,
+			removedNodes: make(map[int]bool),
+			removedEdges: make(map[[2]int]bool),
+		}
+		// 移除與已找到的路徑共用相同前綴時的下一條邊，避免重複
+		for _, p := range it.found {
+			if len(p.Nodes) > i+1 && slices.Equal(p.Nodes[:i+1], root) {
+				masked.removedEdges[[2]int{p.Nodes[i], p.Nodes[i+1]}] = true
+			}
+		}
+		// 移除前綴中除偏離點以外的節點，保證路徑不含重複節點
+		for _, node := range root[:i] {
+			masked.removedNodes[node] = true
+		}
+
+		spurPath, err := dijkstraPath(masked, spur, it.target, guard)
+		if err != nil {
+			if err = ignoreNoPath(err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		nodes := append(append([]int{}, root[:i]...), spurPath.Nodes...)
+		key := pathKey(nodes)
+		if it.seen[key] {
+			continue
+		}
+		candidate, err := newPath(it.graph, nodes)
+		if err != nil {
+			return nil, err
+		}
+		it.seen[key] = true
+		it.candidates = append(it.candidates, candidate)
+		// 成本相同時優先選擇節點數較少的路徑
+		heap.Push(it.pq, &Item{value: len(it.candidates) - 1, priority: candidate.Cost, tiebreak: float64(len(nodes))})
+	}
+
+	if it.pq.Len() == 0 {
+		return nil, nil
+	}
+	index := heap.Pop(it.pq).(*Item).value
+	path := it.candidates[index]
+	it.candidates[index] = nil // 釋放已取出的候選路徑
+	return path, nil
+}
+
+// maskedGraph 隱藏部分節點與邊的圖，用於在不修改原圖的情況下搜索
+type maskedGraph struct {
+	Graph
+	removedNodes map[int]bool
+	removedEdges map[[2]int]bool
+}
+
+func (m *maskedGraph) GetNeighbors(node int) ([]Edge, error) {
+	neighbors, err := m.Graph.GetNeighbors(node)
+	if err != nil {
+		return nil, err
+	}
+	if m.removedNodes[node] {
+		return nil, nil
+	}
+	kept := make([]Edge, 0, len(neighbors))
+	for _, edge := range neighbors {
+		if !m.removedNodes[edge.To] && !m.removedEdges[[2]int{node, edge.To}] {
+			kept = append(kept, edge)
+		}
+	}
+	return kept, nil
+}
+
+func (m *maskedGraph) GetEdges(node int) ([]Edge, error) {
+	return m.GetNeighbors(node)
+}
+
+// ignoreNoPath 將 ErrNoPath 視為正常情況（返回 nil），其他錯誤原樣返回
+func ignoreNoPath(err error) error {
+	if errors.Is(err, ErrNoPath) {
+		return nil
+	}
+	return err
+}
+
+// pathKey 將節點序列轉換為可作為 map 鍵的字串
+func pathKey(nodes []int) string {
+	return fmt.Sprint(nodes)
+}
@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestKShortestPaths(t *testing.T) {
+	// Yen 算法論文中的經典範例（C=1, D=2, E=3, F=4, G=5, H=6）
+	g := NewAdjacencyList(true, true)
+	for i := 1; i <= 6; i++ {
+		g.AddNode(i)
+	}
+	edges := []struct {
+		from, to int
+		weight   float64
+	}{
+		{1, 2, 3}, {1, 3, 2}, {2, 4, 4}, {3, 2, 1},
+		{3, 4, 2}, {3, 5, 3}, {4, 5, 2}, {4, 6, 1}, {5, 6, 2},
+	}
+	for _, edge := range edges {
+		g.AddEdge(edge.from, edge.to, edge.weight)
+	}
+
+	paths, err := KShortestPaths(g, 1, 6, 3)
+	if err != nil {
+		t.Fatalf("KShortestPaths failed: %v", err)
+	}
+	expected := []struct {
+		nodes []int
+		cost  float64
+	}{
+		{[]int{1, 3, 4, 6}, 5},
+		{[]int{1, 3, 5, 6}, 7},
+		{[]int{1, 2, 4, 6}, 8},
+	}
+	if len(paths) != len(expected) {
+		t.Fatalf("Expected %d paths, got %d", len(expected), len(paths))
+	}
+	for i, want := range expected {
+		if paths[i].Cost != want.cost || pathKey(paths[i].Nodes) != pathKey(want.nodes) {
+			t.Errorf("Path %d: got %v (%f), want %v (%f)", i, paths[i].Nodes, paths[i].Cost, want.nodes, want.cost)
+		}
+	}
+
+	// 迭代器應依成本遞增產生所有簡單路徑且不重複
+	it, err := NewKShortestPathIterator(g, 1, 6)
+	if err != nil {
+		t.Fatalf("NewKShortestPathIterator failed: %v", err)
+	}
+	seen := make(map[string]bool)
+	last := 0.0
+	for it.HasNext() {
+		path, err := it.Next()
+		if err != nil {
+			t.Fatalf("Next failed: %v", err)
+		}
+		if path.Cost < last {
+			t.Errorf("Paths are not in increasing cost order: %f after %f", path.Cost, last)
+		}
+		last = path.Cost
+		key := pathKey(path.Nodes)
+		if seen[key] {
+			t.Errorf("Duplicate path %v", path.Nodes)
+		}
+		seen[key] = true
+	}
+	if len(seen) != 7 {
+		t.Errorf("Expected 7 simple paths, got %d", len(seen))
+	}
+	if _, err := it.Next(); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath after exhausting the iterator, got %v", err)
+	}
+}
+
+func TestKShortestPathsNegativeWeight(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	for i := 0; i < 3; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, -1)
+	if _, err := KShortestPaths(g, 0, 2, 2); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Expected ErrNegativeWeight, got %v", err)
+	}
+	// 無法以 O(1) 檢查的圖在搜索展開負權重的邊時返回錯誤
+	if _, err := KShortestPaths(plainGraph{g}, 0, 2, 2); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Expected ErrNegativeWeight from the search, got %v", err)
+	}
+}
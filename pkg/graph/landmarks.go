@@ -0,0 +1,260 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// LandmarkStrategy 決定 SelectLandmarks 選擇地標的方式
+type LandmarkStrategy int
+
+const (
+	LandmarkRandom   LandmarkStrategy = iota // 隨機選擇
+	LandmarkFarthest                         // 每次選擇離已選地標最遠的節點
+	LandmarkAvoid                            // 選擇目前下界最差的最短路徑樹分支末端（Goldberg–Werneck 的 avoid 策略）
+)
+
+// LandmarkOptions 控制地標的選擇
+type LandmarkOptions struct {
+	Count    int              // 地標數量，超過節點數時選擇所有節點
+	Strategy LandmarkStrategy // 選擇策略
+	Seed     int64            // 隨機數種子，相同的種子產生相同的地標
+}
+
+// Landmarks 保存地標以及每個節點與地標之間的最短距離，
+// 可依三角不等式為 A* 提供不需要座標的可採納啟發式函數（ALT 算法）。
+type Landmarks struct {
+	Nodes []int             // 地標節點
+	from  []map[int]float64 // from[i][v]：從地標 i 到 v 的最短距離，只包含可到達的節點
+	to    []map[int]float64 // to[i][v]：從 v 到地標 i 的最短距離，只包含可到達的節點
+}
+
+// NewLandmarks 以指定的節點作為地標，預先計算它們與所有節點之間的最短距離。
+// 有向圖需要正向與反向各一次 Dijkstra；無向圖只需要一次。
+func NewLandmarks(g Graph, nodes []int) (*Landmarks, error) {
+	if err := checkLandmarkGraph(g); err != nil {
+		return nil, err
+	}
+	l := &Landmarks{}
+	for _, node := range nodes {
+		if err := l.add(g, node); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// SelectLandmarks 依指定策略選擇地標並預先計算距離。
+//
+// Example:
+// landmarks, _ := SelectLandmarks(g, LandmarkOptions{Count: 8, Strategy: LandmarkAvoid})
+// path, _ := AStar(g, 1, 42, landmarks.Heuristic())
+func SelectLandmarks(g Graph, opts LandmarkOptions) (*Landmarks, error) {
+	if err := checkLandmarkGraph(g); err != nil {
+		return nil, err
+	}
+	if opts.Count <= 0 {
+		return nil, fmt.Errorf("landmark count must be positive, got %d", opts.Count)
+	}
+
+	nodes := g.GetNodes()
+	sort.Ints(nodes)
+	count := min(opts.Count, len(nodes))
+	rng := rand.New(rand.NewSource(opts.Seed))
+	l := &Landmarks{}
+	chosen := make(map[int]bool)
+
+	for len(l.Nodes) < count {
+		var next int
+		switch {
+		case opts.Strategy == LandmarkRandom || len(l.Nodes) == 0 && opts.Strategy == LandmarkFarthest:
+			next = randomUnchosen(rng, nodes, chosen)
+		case opts.Strategy == LandmarkFarthest:
+			next = l.farthest(nodes, chosen)
+		case opts.Strategy == LandmarkAvoid:
+			var err error
+			if next, err = l.avoid(g, rng.Intn(len(nodes)), nodes, chosen); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unknown landmark strategy %d", opts.Strategy)
+		}
+		chosen[next] = true
+		if err := l.add(g, next); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// Heuristic 返回 ALT 啟發式函數：對每個地標 L，由三角不等式
+// d(v, t) >= d(L, t) - d(L, v) 與 d(v, t) >= d(v, L) - d(t, L)，取所有下界中的最大值。
+// 只要圖與計算地標距離時相同，此估計值就是可採納且一致的。
+func (l *Landmarks) Heuristic() Heuristic {
+	return l.lowerBound
+}
+
+// lowerBound 返回 d(node, goal) 的下界，距離未知的項目略過
+func (l *Landmarks) lowerBound(node, goal int) float64 {
+	best := 0.0
+	for i := range l.Nodes {
+		if dv, ok := l.from[i][node]; ok {
+			if dt, ok := l.from[i][goal]; ok {
+				best = math.Max(best, dt-dv)
+			}
+		}
+		if dv, ok := l.to[i][node]; ok {
+			if dt, ok := l.to[i][goal]; ok {
+				best = math.Max(best, dv-dt)
+			}
+		}
+	}
+	return best
+}
+
+// add 加入一個地標並計算它的正向與反向距離
+func (l *Landmarks) add(g Graph, landmark int) error {
+	guard := SearchOptions{}.newGuard()
+	defer guard.release()
+
+	from, _, err := dijkstraSearch(g, landmark, 0, false, guard)
+	if err != nil {
+		return err
+	}
+	to := from
+	if g.IsDirected() {
+		inNeighbors, err := inNeighborFunc(g)
+		if err != nil {
+			return err
+		}
+		if to, _, err = dijkstraSearch(&reversedGraph{Graph: g, inNeighbors: inNeighbors}, landmark, 0, false, guard); err != nil {
+			return err
+		}
+	}
+
+	l.Nodes = append(l.Nodes, landmark)
+	l.from = append(l.from, from)
+	l.to = append(l.to, to)
+	return nil
+}
+
+// farthest 返回離已選地標最遠（到最近地標的距離最大）的節點；
+// 任何地標都到達不了的節點優先，以覆蓋其他連通分量
+func (l *Landmarks) farthest(nodes []int, chosen map[int]bool) int {
+	best, bestDist := 0, math.Inf(-1)
+	for _, v := range nodes {
+		if chosen[v] {
+			continue
+		}
+		nearest := math.Inf(1)
+		for i := range l.Nodes {
+			if d, ok := l.from[i][v]; ok {
+				nearest = math.Min(nearest, d)
+			}
+			if d, ok := l.to[i][v]; ok {
+				nearest = math.Min(nearest, d)
+			}
+		}
+		if nearest > bestDist {
+			best, bestDist = v, nearest
+		}
+	}
+	return best
+}
+
+// avoid 從 nodes[rootIndex] 建立最短路徑樹，以「實際距離減去目前下界」作為每個節點的權重，
+// 沿著權重總和最大、且不含地標的子樹往下走到葉節點，選擇該葉節點作為新地標。
+// 若所有分支都已被地標覆蓋，退回 farthest 策略。
+func (l *Landmarks) avoid(g Graph, rootIndex int, nodes []int, chosen map[int]bool) (int, error) {
+	root := nodes[rootIndex]
+	guard := SearchOptions{}.newGuard()
+	defer guard.release()
+	distances, predecessors, err := dijkstraSearch(g, root, 0, false, guard)
+	if err != nil {
+		return 0, err
+	}
+
+	children := make(map[int][]int)
+	for v, parent := range predecessors {
+		children[parent] = append(children[parent], v)
+	}
+	for _, list := range children {
+		sort.Ints(list)
+	}
+
+	// 後序計算每棵子樹的權重總和；含有地標的子樹權重為 0
+	size := make(map[int]float64, len(distances))
+	covered := make(map[int]bool)
+	stack := []int{root}
+	visited := make(map[int]bool)
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		if !visited[v] {
+			visited[v] = true
+			stack = append(stack, children[v]...)
+			continue
+		}
+		stack = stack[:len(stack)-1]
+		covered[v] = chosen[v]
+		total := distances[v] - l.lowerBound(root, v)
+		for _, child := range children[v] {
+			covered[v] = covered[v] || covered[child]
+			total += size[child]
+		}
+		if !covered[v] {
+			size[v] = total
+		}
+	}
+
+	// 從根往下，每次走向權重最大的子樹，直到沒有權重為正的子樹
+	current := root
+	for {
+		next, nextSize := 0, 0.0
+		for _, child := range children[current] {
+			if size[child] > nextSize {
+				next, nextSize = child, size[child]
+			}
+		}
+		if nextSize == 0 {
+			break
+		}
+		current = next
+	}
+	if current == root || chosen[current] {
+		return l.farthest(nodes, chosen), nil
+	}
+	return current, nil
+}
+
+// reversedGraph 將所有邊反向的圖，用於計算到某節點的距離
+type reversedGraph struct {
+	Graph
+	inNeighbors func(node int) ([]Edge, error)
+}
+
+func (r *reversedGraph) GetNeighbors(node int) ([]Edge, error) {
+	return r.inNeighbors(node)
+}
+
+func (r *reversedGraph) GetEdges(node int) ([]Edge, error) {
+	return r.inNeighbors(node)
+}
+
+// checkLandmarkGraph 檢查圖是否可以計算地標距離
+func checkLandmarkGraph(g Graph) error {
+	if !g.IsWeighted() {
+		return fmt.Errorf("landmarks require a weighted graph")
+	}
+	return checkNonNegativeWeights(g)
+}
+
+// randomUnchosen 隨機返回一個尚未被選擇的節點
+func randomUnchosen(rng *rand.Rand, nodes []int, chosen map[int]bool) int {
+	for {
+		if v := nodes[rng.Intn(len(nodes))]; !chosen[v] {
+			return v
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestLandmarkHeuristicAdmissible(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	g := NewAdjacencyList(true, true)
+	const n = 150
+	for i := 0; i < n; i++ {
+		g.AddNode(i)
+	}
+	for i := 0; i < n*4; i++ {
+		g.AddEdge(rng.Intn(n), rng.Intn(n), float64(1+rng.Intn(30)))
+	}
+
+	strategies := map[string]LandmarkStrategy{
+		"random":   LandmarkRandom,
+		"farthest": LandmarkFarthest,
+		"avoid":    LandmarkAvoid,
+	}
+	for name, strategy := range strategies {
+		landmarks, err := SelectLandmarks(g, LandmarkOptions{Count: 6, Strategy: strategy, Seed: 7})
+		if err != nil {
+			t.Fatalf("%s: SelectLandmarks failed: %v", name, err)
+		}
+		if len(landmarks.Nodes) != 6 {
+			t.Fatalf("%s: expected 6 landmarks, got %v", name, landmarks.Nodes)
+		}
+		seen := map[int]bool{}
+		for _, node := range landmarks.Nodes {
+			if seen[node] {
+				t.Fatalf("%s: duplicate landmark in %v", name, landmarks.Nodes)
+			}
+			seen[node] = true
+		}
+
+		h := landmarks.Heuristic()
+		for trial := 0; trial < 100; trial++ {
+			start, goal := rng.Intn(n), rng.Intn(n)
+			expected, err := ShortestPath(g, start, goal)
+			if err != nil {
+				continue
+			}
+			if est := h(start, goal); est > expected.Cost+1e-9 {
+				t.Fatalf("%s: heuristic %f exceeds distance %f from %d to %d", name, est, expected.Cost, start, goal)
+			}
+			result, err := AStarSearch(g, start, goal, h, AStarOptions{})
+			if err != nil {
+				t.Fatalf("%s: AStarSearch failed: %v", name, err)
+			}
+			if math.Abs(result.Path.Cost-expected.Cost) > 1e-9 {
+				t.Fatalf("%s: A* cost %f, want %f", name, result.Path.Cost, expected.Cost)
+			}
+		}
+	}
+}
+
+func TestLandmarksReduceExpansions(t *testing.T) {
+	g, _ := buildGrid(40, 40, nil)
+	landmarks, err := SelectLandmarks(g, LandmarkOptions{Count: 4, Strategy: LandmarkFarthest, Seed: 1})
+	if err != nil {
+		t.Fatalf("SelectLandmarks failed: %v", err)
+	}
+	zero := func(node, goal int) float64 { return 0 }
+	plain, err := AStarSearch(g, 0, 1599, zero, AStarOptions{})
+	if err != nil {
+		t.Fatalf("AStarSearch failed: %v", err)
+	}
+	alt, err := AStarSearch(g, 0, 1599, landmarks.Heuristic(), AStarOptions{})
+	if err != nil {
+		t.Fatalf("AStarSearch failed: %v", err)
+	}
+	if alt.Path.Cost != plain.Path.Cost {
+		t.Errorf("Expected cost %f, got %f", plain.Path.Cost, alt.Path.Cost)
+	}
+	if alt.Expanded >= plain.Expanded {
+		t.Errorf("Expected landmarks to expand fewer nodes: %d vs %d", alt.Expanded, plain.Expanded)
+	}
+}
+
+func TestSelectLandmarksOptions(t *testing.T) {
+	g, _ := buildGrid(10, 10, nil)
+	a, _ := SelectLandmarks(g, LandmarkOptions{Count: 3, Strategy: LandmarkAvoid, Seed: 5})
+	b, _ := SelectLandmarks(g, LandmarkOptions{Count: 3, Strategy: LandmarkAvoid, Seed: 5})
+	if !reflect.DeepEqual(a.Nodes, b.Nodes) {
+		t.Errorf("Expected the same seed to give the same landmarks: %v vs %v", a.Nodes, b.Nodes)
+	}
+	all, err := SelectLandmarks(g, LandmarkOptions{Count: 500})
+	if err != nil || len(all.Nodes) != 100 {
+		t.Errorf("Expected all 100 nodes as landmarks, got %d (%v)", len(all.Nodes), err)
+	}
+	if _, err := SelectLandmarks(g, LandmarkOptions{Count: 0}); err == nil {
+		t.Errorf("Expected error for zero landmarks")
+	}
+	if _, err := NewLandmarks(g, []int{1000}); err == nil {
+		t.Errorf("Expected error for unknown landmark")
+	}
+	if _, err := NewLandmarks(NewAdjacencyList(true, false), nil); err == nil {
+		t.Errorf("Expected error for unweighted graph")
+	}
+}
@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// MultiSourceDijkstra 以一次 Dijkstra 同時從多個起點（例如多個倉庫）出發，
+// 計算每個節點到最近起點的距離，以及該起點是哪一個。
+// 效果等同於加入一個以零權重邊連到所有起點的虛擬節點後執行 Dijkstra。
+// 距離相同時，節點歸屬 ID 較小的起點。
+//
+// Returns:
+// - distances: 到最近起點的距離，無法到達的節點為 math.Inf(1)。
+// - predecessors: 前驅節點表，PathTo(predecessors, nearest[v], v) 可重建路徑。
+// - nearest: 每個可到達節點最近的起點。
+//
+// Example:
+// distances, _, nearest, _ := MultiSourceDijkstra(g, []int{1, 7, 12})
+// fmt.Println(nearest[5], distances[5])
+func MultiSourceDijkstra(g Graph, sources []int) (distances map[int]float64, predecessors map[int]int, nearest map[int]int, err error) {
+	if !g.IsWeighted() {
+		return nil, nil, nil, fmt.Errorf("Dijkstra requires a weighted graph")
+	}
+	if len(sources) == 0 {
+		return nil, nil, nil, fmt.Errorf("at least one source is required")
+	}
+	for _, source := range sources {
+		if _, err := g.GetNeighbors(source); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if err := precheckNonNegativeWeights(g); err != nil {
+		return nil, nil, nil, err
+	}
+
+	distances = make(map[int]float64)
+	predecessors = make(map[int]int)
+	nearest = make(map[int]int)
+	visited := make(map[int]bool)
+	pq := NewPriorityQueue()
+	for _, source := range sources {
+		if owner, ok := nearest[source]; !ok || source < owner {
+			distances[source] = 0
+			nearest[source] = source
+		}
+	}
+	for source := range nearest {
+		heap.Push(pq, &Item{value: source, priority: 0, tiebreak: float64(source)})
+	}
+
+	for pq.Len() > 0 {
+		u := heap.Pop(pq).(*Item).value
+		if visited[u] {
+			continue
+		}
+		visited[u] = true
+
+		neighbors, err := g.GetNeighbors(u)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for _, edge := range neighbors {
+			if err := checkEdgeWeight(u, edge); err != nil {
+				return nil, nil, nil, err
+			}
+			v := edge.To
+			if visited[v] {
+				continue
+			}
+			alt := distances[u] + edge.Weight
+			d, ok := distances[v]
+			if !ok || alt < d || alt == d && nearest[u] < nearest[v] {
+				distances[v] = alt
+				predecessors[v] = u
+				nearest[v] = nearest[u]
+				// 距離相同時優先確定屬於 ID 較小起點的節點
+				heap.Push(pq, &Item{value: v, priority: alt, tiebreak: float64(nearest[u])})
+			}
+		}
+	}
+
+	for _, node := range g.GetNodes() {
+		if _, ok := distances[node]; !ok {
+			distances[node] = math.Inf(1)
+		}
+	}
+	return distances, predecessors, nearest, nil
+}
+
+// GraphVoronoi 將圖劃分為以各起點為中心的 Voronoi 區域：每個節點屬於距離最近的起點。
+// 返回每個起點所擁有的節點（遞增排列，包含起點本身），任何起點都無法到達的節點不屬於任何區域。
+//
+// Example:
+// cells, _ := GraphVoronoi(g, []int{1, 7, 12})
+// fmt.Println(cells[7])
+func GraphVoronoi(g Graph, sources []int) (map[int][]int, error) {
+	_, _, nearest, err := MultiSourceDijkstra(g, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	cells := make(map[int][]int, len(sources))
+	for _, source := range sources {
+		cells[source] = []int{}
+	}
+	for node, source := range nearest {
+		cells[source] = append(cells[source], node)
+	}
+	for _, cell := range cells {
+		sort.Ints(cell)
+	}
+	return cells, nil
+}
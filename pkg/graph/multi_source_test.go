@@ -0,0 +1,107 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestMultiSourceDijkstra(t *testing.T) {
+	rng := rand.New(rand.NewSource(45))
+	g := NewAdjacencyList(true, true)
+	const n = 120
+	for i := 0; i < n; i++ {
+		g.AddNode(i)
+	}
+	for i := 0; i < n*3; i++ {
+		g.AddEdge(rng.Intn(n), rng.Intn(n), float64(1+rng.Intn(20)))
+	}
+	sources := []int{3, 40, 77}
+
+	distances, predecessors, nearest, err := MultiSourceDijkstra(g, sources)
+	if err != nil {
+		t.Fatalf("MultiSourceDijkstra failed: %v", err)
+	}
+
+	// 與逐一執行 Dijkstra 後合併的結果比較
+	perSource := map[int]map[int]float64{}
+	for _, source := range sources {
+		perSource[source], _, _ = Dijkstra(g, source)
+	}
+	for node := 0; node < n; node++ {
+		best, owner := math.Inf(1), 0
+		for _, source := range sources {
+			if d := perSource[source][node]; d < best {
+				best, owner = d, source
+			}
+		}
+		if distances[node] != best {
+			t.Fatalf("Distance of %d: got %v, want %v", node, distances[node], best)
+		}
+		if math.IsInf(best, 1) {
+			if _, ok := nearest[node]; ok {
+				t.Errorf("Unreachable node %d should have no nearest source", node)
+			}
+			continue
+		}
+		if nearest[node] != owner {
+			t.Errorf("Nearest source of %d: got %d, want %d", node, nearest[node], owner)
+		}
+		nodes, err := PathTo(predecessors, nearest[node], node)
+		if err != nil {
+			t.Fatalf("PathTo failed for %d: %v", node, err)
+		}
+		if path, _ := newPath(g, nodes); path.Cost != best {
+			t.Errorf("Path to %d costs %v, want %v", node, path.Cost, best)
+		}
+	}
+}
+
+func TestGraphVoronoi(t *testing.T) {
+	// 0 - 1 - 2 - 3 - 4 - 5，起點為 0 與 5；2 與 3 分別靠近 0 與 5
+	g := NewAdjacencyList(false, true)
+	for i := 0; i <= 6; i++ {
+		g.AddNode(i)
+	}
+	for i := 0; i < 5; i++ {
+		g.AddEdge(i, i+1, 1)
+	}
+
+	cells, err := GraphVoronoi(g, []int{5, 0})
+	if err != nil {
+		t.Fatalf("GraphVoronoi failed: %v", err)
+	}
+	want := map[int][]int{0: {0, 1, 2}, 5: {3, 4, 5}}
+	if !reflect.DeepEqual(cells, want) {
+		t.Errorf("Expected %v, got %v", want, cells)
+	}
+
+	// 與兩個起點距離相同的節點歸屬 ID 較小者
+	cells, _ = GraphVoronoi(g, []int{4, 0})
+	if want := map[int][]int{0: {0, 1, 2}, 4: {3, 4, 5}}; !reflect.DeepEqual(cells, want) {
+		t.Errorf("Expected %v, got %v", want, cells)
+	}
+
+	if _, err := GraphVoronoi(g, nil); err == nil {
+		t.Errorf("Expected error for no sources")
+	}
+	if _, err := GraphVoronoi(g, []int{99}); err == nil {
+		t.Errorf("Expected error for unknown source")
+	}
+}
+
+func TestMultiSourceDijkstraNegativeWeight(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	for i := 0; i < 3; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(2, 1, -1)
+	for _, graph := range []Graph{g, plainGraph{g}} {
+		if _, _, _, err := MultiSourceDijkstra(graph, []int{0, 2}); !errors.Is(err, ErrNegativeWeight) {
+			t.Errorf("Expected ErrNegativeWeight, got %v", err)
+		}
+	}
+}
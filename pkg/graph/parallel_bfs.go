@@ -0,0 +1,160 @@
+package graph
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelBFSOptions 控制 ParallelBFS 的行為
+type ParallelBFSOptions struct {
+	Workers  int // 展開每一層時使用的 goroutine 數量，小於等於 0 表示使用 runtime.GOMAXPROCS(0)
+	MaxDepth int // 最大搜索深度（跳數），小於等於 0 表示不限制
+}
+
+// ParallelBFS performs a level-synchronous breadth-first search, expanding each
+// frontier level across a pool of goroutines. Nodes are claimed with atomic
+// compare-and-swap, so every node is assigned to exactly one level.
+//
+// The graph must be safe for concurrent reads (AdjacencyList is, as long as it
+// is not modified during the search).
+//
+// Parameters:
+// - g: The graph to traverse (must implement the Graph interface).
+// - sources: The starting nodes; all of them are at depth 0.
+// - opts: Worker count and maximum depth.
+//
+// Returns:
+// - A BFSResult whose Depth and level membership are identical to BFSTree.
+// Nodes inside each level are sorted in ascending order, and when several
+// parents could discover a node, any one of them may be recorded in Parent.
+// - An error if a source node does not exist or a neighbor lookup fails.
+//
+// Example:
+// result, _ := ParallelBFS(g, []int{1}, ParallelBFSOptions{Workers: 8})
+// fmt.Println(result.Depth)
+func ParallelBFS(g Graph, sources []int, opts ParallelBFSOptions) (*BFSResult, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("ParallelBFS requires at least one source node")
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// 將節點 ID 映射為連續索引，以便使用原子操作標記訪問狀態
+	nodes := g.GetNodes()
+	index := make(map[int]int, len(nodes))
+	for i, node := range nodes {
+		index[node] = i
+	}
+	visited := make([]int32, len(nodes))
+
+	result := &BFSResult{
+		Order:  []int{},
+		Depth:  make(map[int]int),
+		Parent: make(map[int]int),
+		Levels: [][]int{},
+	}
+
+	frontier := []int{}
+	for _, source := range sources {
+		i, exists := index[source]
+		if !exists {
+			return nil, fmt.Errorf("node %d does not exist in the graph", source)
+		}
+		if atomic.CompareAndSwapInt32(&visited[i], 0, 1) {
+			result.Depth[source] = 0
+			frontier = append(frontier, source)
+		}
+	}
+	sort.Ints(frontier)
+
+	for depth := 0; len(frontier) > 0; depth++ {
+		result.Levels = append(result.Levels, frontier)
+		result.Order = append(result.Order, frontier...)
+		if opts.MaxDepth > 0 && depth >= opts.MaxDepth {
+			break // 已達最大深度，不再展開
+		}
+
+		discovered, err := expandFrontier(g, frontier, index, visited, workers)
+		if err != nil {
+			return nil, err
+		}
+
+		// 單執行緒合併各 worker 的結果，避免並發寫入 map
+		next := []int{}
+		for _, local := range discovered {
+			for _, d := range local {
+				result.Depth[d.node] = depth + 1
+				result.Parent[d.node] = d.parent
+				next = append(next, d.node)
+			}
+		}
+		sort.Ints(next)
+		frontier = next
+	}
+	return result, nil
+}
+
+// discoveredNode 記錄在展開過程中新發現的節點及其父節點
+type discoveredNode struct {
+	node   int
+	parent int
+}
+
+// expandFrontier 將目前這一層的節點分配給多個 goroutine 並行展開，
+// 返回每個 worker 新發現的節點
+func expandFrontier(g Graph, frontier []int, index map[int]int, visited []int32, workers int) ([][]discoveredNode, error) {
+	if workers > len(frontier) {
+		workers = len(frontier)
+	}
+	chunk := (len(frontier) + workers - 1) / workers
+	discovered := make([][]discoveredNode, workers)
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	for w := 0; w < workers; w++ {
+		lo := w * chunk
+		hi := lo + chunk
+		if hi > len(frontier) {
+			hi = len(frontier)
+		}
+		if lo >= hi {
+			continue
+		}
+
+		wg.Add(1)
+		go func(w int, part []int) {
+			defer wg.Done()
+			local := []discoveredNode{}
+			for _, node := range part {
+				neighbors, err := g.GetNeighbors(node)
+				if err != nil {
+					once.Do(func() { firstErr = err })
+					return
+				}
+				for _, edge := range neighbors {
+					i, exists := index[edge.To]
+					if !exists {
+						continue
+					}
+					// 只有成功將標記從 0 改為 1 的 worker 擁有該節點
+					if atomic.CompareAndSwapInt32(&visited[i], 0, 1) {
+						local = append(local, discoveredNode{node: edge.To, parent: node})
+					}
+				}
+			}
+			discovered[w] = local
+		}(w, frontier[lo:hi])
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return discovered, nil
+}
@@ -0,0 +1,57 @@
+package graph
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestParallelBFSMatchesBFSTree(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	g := NewAdjacencyList(true, false)
+	const n = 2000
+	for i := 0; i < n; i++ {
+		g.AddNode(i * 3) // 使用不連續的節點 ID
+	}
+	for i := 0; i < n*4; i++ {
+		g.AddEdge(rng.Intn(n)*3, rng.Intn(n)*3, 0)
+	}
+
+	for _, maxDepth := range []int{0, 3} {
+		expected, err := BFSTree(g, []int{0, 30}, BFSOptions{MaxDepth: maxDepth})
+		if err != nil {
+			t.Fatalf("BFSTree failed: %v", err)
+		}
+		result, err := ParallelBFS(g, []int{0, 30}, ParallelBFSOptions{Workers: 8, MaxDepth: maxDepth})
+		if err != nil {
+			t.Fatalf("ParallelBFS failed: %v", err)
+		}
+
+		if len(result.Depth) != len(expected.Depth) {
+			t.Fatalf("Expected %d reached nodes, got %d", len(expected.Depth), len(result.Depth))
+		}
+		for node, depth := range expected.Depth {
+			if result.Depth[node] != depth {
+				t.Errorf("Incorrect depth for node %d: got %d, want %d", node, result.Depth[node], depth)
+			}
+		}
+		if len(result.Levels) != len(expected.Levels) {
+			t.Fatalf("Expected %d levels, got %d", len(expected.Levels), len(result.Levels))
+		}
+		for d := range expected.Levels {
+			want := append([]int(nil), expected.Levels[d]...)
+			sort.Ints(want)
+			for i := range want {
+				if result.Levels[d][i] != want[i] {
+					t.Fatalf("Level %d differs: got %v, want %v", d, result.Levels[d], want)
+				}
+			}
+		}
+		// 每個父節點都必須位於上一層
+		for node, parent := range result.Parent {
+			if result.Depth[parent] != result.Depth[node]-1 {
+				t.Errorf("Parent %d of node %d is not on the previous level", parent, node)
+			}
+		}
+	}
+}
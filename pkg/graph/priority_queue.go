@@ -8,6 +8,7 @@ import (
 type Item struct {
 	value    int     // 節點值
 	priority float64 // 優先級（在Dijkstra算法中表示距離）
+	tiebreak float64 // 次要優先級，優先級相同時數值較小者先出隊
 	index    int     // 在堆中的索引
 }
 
@@ -17,6 +18,9 @@ type PriorityQueue []*Item
 func (pq PriorityQueue) Len() int { return len(pq) }
 
 func (pq PriorityQueue) Less(i, j int) bool {
+	if pq[i].priority == pq[j].priority {
+		return pq[i].tiebreak < pq[j].tiebreak
+	}
 	return pq[i].priority < pq[j].priority
 }
 
@@ -0,0 +1,65 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSearchCanceled 表示搜索因 context 被取消或逾時而中止。
+// 返回的錯誤同時包裝了 context 的錯誤，可用 errors.Is 判斷是 context.Canceled 還是 context.DeadlineExceeded。
+var ErrSearchCanceled = errors.New("search canceled")
+
+// ErrBudgetExceeded 表示搜索展開的節點數超過了 SearchOptions.MaxExpanded
+var ErrBudgetExceeded = errors.New("search budget exceeded")
+
+// SearchOptions 控制長時間執行的演算法的取消、資源限制與邊的成本。
+// 零值表示不限制，行為與不帶選項的版本相同。
+//
+// 被中止的演算法會返回目前為止的部分結果，以及包裝了
+// ErrSearchCanceled 或 ErrBudgetExceeded 的錯誤。
+type SearchOptions struct {
+	Context     context.Context // 用於取消搜索，nil 表示 context.Background()
+	Timeout     time.Duration   // 搜索的逾時時間，小於等於 0 表示不限制
+	MaxExpanded int             // 最多展開的節點數，小於等於 0 表示不限制
+	Weight      WeightFunc      // 自訂的邊成本，nil 表示使用 Edge.Weight；只影響加權搜索（Dijkstra、A* 等）
+}
+
+// searchGuard 在演算法執行期間追蹤取消狀態與展開節點數
+type searchGuard struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	maxExpanded int
+	expanded    int
+}
+
+// newGuard 根據選項建立 searchGuard，使用完畢後必須呼叫 release
+func (o SearchOptions) newGuard() *searchGuard {
+	ctx := o.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cancel := context.CancelFunc(func() {})
+	if o.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+	}
+	return &searchGuard{ctx: ctx, cancel: cancel, maxExpanded: o.MaxExpanded}
+}
+
+// expand 記錄一次節點展開，若搜索應該中止則返回錯誤
+func (s *searchGuard) expand() error {
+	if err := s.ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrSearchCanceled, err)
+	}
+	s.expanded++
+	if s.maxExpanded > 0 && s.expanded > s.maxExpanded {
+		return fmt.Errorf("%w: more than %d nodes expanded", ErrBudgetExceeded, s.maxExpanded)
+	}
+	return nil
+}
+
+// release 釋放 guard 持有的資源
+func (s *searchGuard) release() {
+	s.cancel()
+}
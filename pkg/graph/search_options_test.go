@@ -0,0 +1,69 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSearchOptionsBudget(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	for i := 0; i < 10; i++ {
+		g.AddNode(i)
+	}
+	for i := 0; i < 9; i++ {
+		g.AddEdge(i, i+1, 1)
+	}
+
+	distances, _, err := DijkstraWithOptions(g, 0, SearchOptions{MaxExpanded: 3})
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("Expected ErrBudgetExceeded, got %v", err)
+	}
+	if distances[2] != 2 {
+		t.Errorf("Expected partial distance 2 for node 2, got %f", distances[2])
+	}
+
+	order, err := BFSWithOptions(g, 0, SearchOptions{MaxExpanded: 4})
+	if !errors.Is(err, ErrBudgetExceeded) || len(order) != 4 {
+		t.Errorf("Expected 4 nodes and ErrBudgetExceeded, got %v, %v", order, err)
+	}
+
+	order, err = DFSWithOptions(g, 0, SearchOptions{MaxExpanded: 5})
+	if !errors.Is(err, ErrBudgetExceeded) || len(order) != 5 {
+		t.Errorf("Expected 5 nodes and ErrBudgetExceeded, got %v, %v", order, err)
+	}
+
+	// 不限制時應與原始版本結果相同
+	order, err = DFSWithOptions(g, 0, SearchOptions{})
+	if err != nil || len(order) != 10 {
+		t.Errorf("Expected full traversal, got %v, %v", order, err)
+	}
+}
+
+func TestSearchOptionsCancel(t *testing.T) {
+	g := NewAdjacencyList(false, true)
+	for i := 0; i < 5; i++ {
+		g.AddNode(i)
+	}
+	for i := 0; i < 4; i++ {
+		g.AddEdge(i, i+1, 1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := DijkstraWithOptions(g, 0, SearchOptions{Context: ctx})
+	if !errors.Is(err, ErrSearchCanceled) || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected ErrSearchCanceled wrapping context.Canceled, got %v", err)
+	}
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+	result, err := BFSTree(g, []int{0}, BFSOptions{Search: SearchOptions{Context: expired}})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if result == nil || len(result.Order) != 1 {
+		t.Errorf("Expected partial result containing only the source, got %v", result)
+	}
+}
@@ -6,39 +6,118 @@ import (
 	"math"
 )
 
-// Dijkstra 實現Dijkstra最短路徑算法
+// Dijkstra 實現Dijkstra最短路徑算法。
+// 圖中含有負權重的邊時返回 ErrNegativeWeight，此時應改用 BellmanFord 或 SPFA。
+// AdjacencyList 以 O(1) 時間檢查；其他圖（例如 WithWeights 視圖）只檢查搜索實際展開的邊。
 func Dijkstra(g Graph, start int) (distances map[int]float64, predecessors map[int]int, err error) {
+	return DijkstraWithOptions(g, start, SearchOptions{})
+}
+
+// DijkstraWithOptions 與 Dijkstra 相同，但可透過 SearchOptions 取消搜索、限制展開的節點數，
+// 或以 Weight 自訂邊的成本。
+// 搜索被中止時，返回目前為止計算出的距離與前驅節點，以及包裝了
+// ErrSearchCanceled 或 ErrBudgetExceeded 的錯誤。
+func DijkstraWithOptions(g Graph, start int, opts SearchOptions) (distances map[int]float64, predecessors map[int]int, err error) {
+	g = opts.graph(g)
 	if !g.IsWeighted() {
 		return nil, nil, fmt.Errorf("Dijkstra requires a weighted graph")
 	}
+	if err := precheckNonNegativeWeights(g); err != nil {
+		return nil, nil, err // 負權重請改用 BellmanFord
+	}
 
-	distances = make(map[int]float64)
-	predecessors = make(map[int]int)
-	visited := make(map[int]bool)
+	guard := opts.newGuard()
+	defer guard.release()
 
-	// 初始化距離為無窮大
-	for node := 0; node < g.NodeCount(); node++ {
-		distances[node] = math.Inf(1)
+	distances, predecessors, err = dijkstraSearch(g, start, start, false, guard)
+
+	// 無法到達的節點距離為無窮大（節點 ID 不一定是 0..n-1，因此以 GetNodes 初始化）
+	if distances != nil {
+		for _, node := range g.GetNodes() {
+			if _, ok := distances[node]; !ok {
+				distances[node] = math.Inf(1)
+			}
+		}
+	}
+	return distances, predecessors, err
+}
+
+// ShortestPath 使用 Dijkstra 算法計算從 start 到 target 的最短路徑，
+// 在 target 的距離確定後立即停止搜索。
+//
+// Example:
+// path, err := ShortestPath(g, 0, 4)
+// fmt.Println(path.Nodes, path.Cost)
+func ShortestPath(g Graph, start, target int) (*Path, error) {
+	return ShortestPathWithOptions(g, start, target, SearchOptions{})
+}
+
+// ShortestPathWithOptions 與 ShortestPath 相同，但可透過 SearchOptions 取消搜索、限制展開的節點數或自訂邊的成本。
+// 使用 Weight 時，返回的 Path 中的邊權重與 Cost 都是自訂的成本。
+func ShortestPathWithOptions(g Graph, start, target int, opts SearchOptions) (*Path, error) {
+	g = opts.graph(g)
+	if !g.IsWeighted() {
+		return nil, fmt.Errorf("Dijkstra requires a weighted graph")
 	}
-	distances[start] = 0
+	if _, err := g.GetNeighbors(target); err != nil {
+		return nil, err
+	}
+	if err := precheckNonNegativeWeights(g); err != nil {
+		return nil, err
+	}
+
+	guard := opts.newGuard()
+	defer guard.release()
+
+	return dijkstraPath(g, start, target, guard)
+}
+
+// dijkstraPath 計算從 start 到 target 的最短路徑，負權重的邊在展開時才檢查
+func dijkstraPath(g Graph, start, target int, guard *searchGuard) (*Path, error) {
+	_, predecessors, err := dijkstraSearch(g, start, target, true, guard)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := PathTo(predecessors, start, target)
+	if err != nil {
+		return nil, err
+	}
+	return newPath(g, nodes)
+}
+
+// dijkstraSearch 是 Dijkstra 算法的核心。距離表只包含已到達的節點；
+// 若 hasTarget 為 true，則在 target 出隊（距離確定）後立即停止。
+func dijkstraSearch(g Graph, start, target int, hasTarget bool, guard *searchGuard) (map[int]float64, map[int]int, error) {
+	if _, err := g.GetNeighbors(start); err != nil {
+		return nil, nil, err // 起點不存在
+	}
+
+	distances := map[int]float64{start: 0}
+	predecessors := make(map[int]int)
+	visited := make(map[int]bool)
 
 	// 創建優先隊列
-	pq := make(PriorityQueue, 0)
-	heap.Init(&pq)
-	heap.Push(&pq, &Item{
+	pq := NewPriorityQueue()
+	heap.Push(pq, &Item{
 		value:    start,
 		priority: 0,
 	})
 
 	for pq.Len() > 0 {
 		// 獲取當前最短距離的節點
-		item := heap.Pop(&pq).(*Item)
+		item := heap.Pop(pq).(*Item)
 		u := item.value
 
 		if visited[u] {
 			continue
 		}
+		if err := guard.expand(); err != nil {
+			return distances, predecessors, err // 返回部分結果
+		}
 		visited[u] = true
+		if hasTarget && u == target {
+			break // 目標節點的距離已確定，提前結束
+		}
 
 		// 檢查所有相鄰節點
 		neighbors, err := g.GetNeighbors(u)
@@ -47,20 +126,88 @@ func Dijkstra(g Graph, start int) (distances map[int]float64, predecessors map[i
 		}
 
 		for _, edge := range neighbors {
+			if err := checkEdgeWeight(u, edge); err != nil {
+				return nil, nil, err
+			}
 			v := edge.To
-			if !visited[v] {
-				alt := distances[u] + edge.Weight
-				if alt < distances[v] {
-					distances[v] = alt
-					predecessors[v] = u
-					heap.Push(&pq, &Item{
-						value:    v,
-						priority: alt,
-					})
-				}
+			if visited[v] {
+				continue
+			}
+			alt := distances[u] + edge.Weight
+			if d, ok := distances[v]; !ok || alt < d {
+				distances[v] = alt
+				predecessors[v] = u
+				heap.Push(pq, &Item{
+					value:    v,
+					priority: alt,
+				})
 			}
 		}
 	}
 
 	return distances, predecessors, nil
 }
+
+// Path 表示圖中的一條路徑
+type Path struct {
+	Nodes []int   // 路徑上的節點，包含起點與終點
+	Edges []Edge  // 路徑上的邊，Edges[i] 連接 Nodes[i] 與 Nodes[i+1]
+	Cost  float64 // 路徑的總成本
+}
+
+// PathTo 沿著前驅節點表重建從 start 到 target 的節點序列。
+// 前驅節點表可以來自 Dijkstra 或其他最短路徑算法，起點不需要出現在表中。
+//
+// Example:
+// _, predecessors, _ := Dijkstra(g, 0)
+// nodes, err := PathTo(predecessors, 0, 4)
+func PathTo(predecessors map[int]int, start, target int) ([]int, error) {
+	path := []int{target}
+	for node := target; node != start; {
+		pred, ok := predecessors[node]
+		if !ok || len(path) > len(predecessors)+1 {
+			// 沒有前驅節點，或前驅節點表中有環
+			return nil, fmt.Errorf("%w: from %d to %d", ErrNoPath, start, target)
+		}
+		node = pred
+		path = append(path, node)
+	}
+
+	// 反轉為從起點到終點的順序
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, nil
+}
+
+// newPath 根據節點序列建立 Path，相鄰節點之間若有多條平行邊則使用權重最小者
+func newPath(g Graph, nodes []int) (*Path, error) {
+	return newPathBy(g, nodes, func(a, b Edge) bool { return a.Weight < b.Weight })
+}
+
+// newPathBy 根據節點序列建立 Path，相鄰節點之間若有多條平行邊，選擇 prefer 認為較好者
+func newPathBy(g Graph, nodes []int, prefer func(a, b Edge) bool) (*Path, error) {
+	path := &Path{
+		Nodes: nodes,
+		Edges: make([]Edge, 0, len(nodes)),
+	}
+	for i := 0; i+1 < len(nodes); i++ {
+		neighbors, err := g.GetNeighbors(nodes[i])
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		var best Edge
+		for _, edge := range neighbors {
+			if edge.To == nodes[i+1] && (!found || prefer(edge, best)) {
+				best, found = edge, true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("edge %d -> %d does not exist", nodes[i], nodes[i+1])
+		}
+		path.Edges = append(path.Edges, best)
+		path.Cost += best.Weight
+	}
+	return path, nil
+}
@@ -0,0 +1,253 @@
+package graph
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ShortestPathDAG 是從單一起點出發的最短路徑有向無環圖：
+// 保存每個節點所有位於某條最短路徑上的前驅節點，而不只是 Dijkstra 的其中一個。
+// 路徑以節點序列區分，平行邊不會被重複計數。
+type ShortestPathDAG struct {
+	Source       int
+	Distances    map[int]float64 // 從起點到每個已到達節點的最短距離
+	Predecessors map[int][]int   // 每個節點所有並列的前驅節點（遞增排列），起點沒有前驅
+	Order        []int           // 已到達節點的拓撲順序（前驅一定排在後繼之前）
+	counts       map[int]float64
+}
+
+// shortestPathTolerance 是判斷兩個距離是否並列時容許的相對誤差
+const shortestPathTolerance = 1e-9
+
+// NewShortestPathDAG 以 Dijkstra 計算從 source 出發的最短路徑 DAG 與每個節點的最短路徑數。
+// 路徑數以 float64 保存，因為在網格等圖上它會呈指數增長。
+//
+// Returns:
+// - The shortest-path DAG.
+// - ErrNegativeWeight for negative edges, or an error if zero-weight cycles make the number of shortest paths infinite.
+//
+// Example:
+// dag, _ := NewShortestPathDAG(g, 0)
+// fmt.Println(dag.PathCount(5))
+//
+//	for it := dag.AllPaths(5); it.HasNext(); {
+//		nodes, _ := it.Next()
+//		fmt.Println(nodes)
+//	}
+func NewShortestPathDAG(g Graph, source int) (*ShortestPathDAG, error) {
+	if !g.IsWeighted() {
+		return nil, fmt.Errorf("Dijkstra requires a weighted graph")
+	}
+	if _, err := g.GetNeighbors(source); err != nil {
+		return nil, err
+	}
+	if err := precheckNonNegativeWeights(g); err != nil {
+		return nil, err
+	}
+
+	dag := &ShortestPathDAG{
+		Source:       source,
+		Distances:    map[int]float64{source: 0},
+		Predecessors: make(map[int][]int),
+	}
+	visited := make(map[int]bool)
+	pq := NewPriorityQueue()
+	heap.Push(pq, &Item{value: source, priority: 0})
+
+	for pq.Len() > 0 {
+		u := heap.Pop(pq).(*Item).value
+		if visited[u] {
+			continue
+		}
+		visited[u] = true
+
+		neighbors, err := g.GetNeighbors(u)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range neighbors {
+			if err := checkEdgeWeight(u, edge); err != nil {
+				return nil, err
+			}
+			v := edge.To
+			alt := dag.Distances[u] + edge.Weight
+			d, reached := dag.Distances[v]
+			switch {
+			case (v == source || u == v) && sameDistance(alt, d):
+				// 以零權重回到起點或零權重自環，與其他零權重環一樣使最短路徑數無限
+				return nil, fmt.Errorf("zero-weight cycle reachable from %d: infinitely many shortest paths", source)
+			case v == source || u == v:
+				// 回到起點或自環的權重為正，不會出現在最短路徑上
+			case !reached || alt < d && !sameDistance(alt, d):
+				dag.Distances[v] = alt
+				dag.Predecessors[v] = []int{u}
+				heap.Push(pq, &Item{value: v, priority: alt})
+			case sameDistance(alt, d) && !slices.Contains(dag.Predecessors[v], u):
+				// 並列的最短路徑；零權重邊可能在 v 確定後才出現
+				dag.Predecessors[v] = append(dag.Predecessors[v], u)
+			}
+		}
+	}
+	for _, preds := range dag.Predecessors {
+		slices.Sort(preds)
+	}
+
+	if err := dag.countPaths(); err != nil {
+		return nil, err
+	}
+	return dag, nil
+}
+
+// PathCount 返回從起點到 node 的最短路徑數，無法到達時為 0
+func (d *ShortestPathDAG) PathCount(node int) float64 {
+	return d.counts[node]
+}
+
+// PathCounts 返回每個已到達節點的最短路徑數
+func (d *ShortestPathDAG) PathCounts() map[int]float64 {
+	counts := make(map[int]float64, len(d.counts))
+	for node, count := range d.counts {
+		counts[node] = count
+	}
+	return counts
+}
+
+// AllPaths 返回逐條產生從起點到 target 所有最短路徑的迭代器
+func (d *ShortestPathDAG) AllPaths(target int) *AllShortestPathsIterator {
+	return &AllShortestPathsIterator{dag: d, target: target}
+}
+
+// countPaths 依拓撲順序累加每個節點的最短路徑數
+func (d *ShortestPathDAG) countPaths() error {
+	// 以 Kahn 算法在前驅圖上求拓撲順序，同時檢查零權重環
+	successors := make(map[int][]int)
+	inDegree := make(map[int]int, len(d.Distances))
+	for v, preds := range d.Predecessors {
+		inDegree[v] = len(preds)
+		for _, u := range preds {
+			successors[u] = append(successors[u], v)
+		}
+	}
+
+	d.Order = []int{d.Source}
+	d.counts = map[int]float64{d.Source: 1}
+	for i := 0; i < len(d.Order); i++ {
+		u := d.Order[i]
+		for _, v := range successors[u] {
+			d.counts[v] += d.counts[u]
+			if inDegree[v]--; inDegree[v] == 0 {
+				d.Order = append(d.Order, v)
+			}
+		}
+	}
+	if len(d.Order) != len(d.Distances) {
+		return fmt.Errorf("zero-weight cycle reachable from %d: infinitely many shortest paths", d.Source)
+	}
+	return nil
+}
+
+// sameDistance 判斷兩個距離在浮點誤差範圍內是否相等
+func sameDistance(a, b float64) bool {
+	return math.Abs(a-b) <= shortestPathTolerance*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+// AllShortestPathsIterator 逐條產生從起點到目標的所有最短路徑，
+// 路徑依前驅節點的字典順序（從目標往回看）產生，不會一次展開所有路徑。
+type AllShortestPathsIterator struct {
+	dag     *ShortestPathDAG
+	target  int
+	frames  []pathFrame // frames[0] 為目標，之後每一層是上一層所選的前驅
+	started bool
+	pending bool // frames 是否保存著尚未返回的路徑
+}
+
+// pathFrame 是回溯路徑上的一個節點，以及目前選擇的前驅索引
+type pathFrame struct {
+	node  int
+	index int
+}
+
+// HasNext 返回是否還有下一條最短路徑
+func (it *AllShortestPathsIterator) HasNext() bool {
+	if it.pending {
+		return true
+	}
+	if !it.started {
+		it.started = true
+		if _, ok := it.dag.Distances[it.target]; ok {
+			it.frames = []pathFrame{{node: it.target}}
+			it.descend()
+			it.pending = true
+		}
+		return it.pending
+	}
+
+	if len(it.frames) == 0 {
+		return false // 已遍歷所有路徑
+	}
+
+	// 找到最靠近起點、還有其他前驅可選的節點，改選下一個前驅
+	it.frames = it.frames[:len(it.frames)-1] // 移除起點
+	for len(it.frames) > 0 {
+		top := &it.frames[len(it.frames)-1]
+		top.index++
+		if top.index < len(it.dag.Predecessors[top.node]) {
+			it.descend()
+			it.pending = true
+			return true
+		}
+		it.frames = it.frames[:len(it.frames)-1]
+	}
+	return false
+}
+
+// Next 返回下一條最短路徑的節點序列（從起點到目標），沒有更多路徑時返回包裝了 ErrNoPath 的錯誤
+func (it *AllShortestPathsIterator) Next() ([]int, error) {
+	if !it.HasNext() {
+		return nil, fmt.Errorf("%w: no more shortest paths from %d to %d", ErrNoPath, it.dag.Source, it.target)
+	}
+	it.pending = false
+	nodes := make([]int, len(it.frames))
+	for i, frame := range it.frames {
+		nodes[len(nodes)-1-i] = frame.node
+	}
+	return nodes, nil
+}
+
+// descend 從最後一層沿著目前選擇的前驅一路走到起點
+func (it *AllShortestPathsIterator) descend() {
+	for {
+		top := it.frames[len(it.frames)-1]
+		if top.node == it.dag.Source {
+			return
+		}
+		it.frames = append(it.frames, pathFrame{node: it.dag.Predecessors[top.node][top.index]})
+	}
+}
+
+// AllShortestPaths 返回從 start 到 target 的所有最短路徑。
+// 路徑數可能呈指數增長，數量很多時請改用 ShortestPathDAG.AllPaths 逐條處理。
+func AllShortestPaths(g Graph, start, target int) ([][]int, error) {
+	if _, err := g.GetNeighbors(target); err != nil {
+		return nil, err
+	}
+	dag, err := NewShortestPathDAG(g, start)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := [][]int{}
+	for it := dag.AllPaths(target); it.HasNext(); {
+		nodes, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, nodes)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w: from %d to %d", ErrNoPath, start, target)
+	}
+	return paths, nil
+}
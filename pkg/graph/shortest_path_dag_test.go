@@ -0,0 +1,134 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestShortestPathDAG(t *testing.T) {
+	// 0 -> 1 -> 3, 0 -> 2 -> 3，兩條長度相同；0 -> 3 較長
+	g := NewAdjacencyList(true, true)
+	for i := 0; i <= 4; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(0, 2, 2)
+	g.AddEdge(1, 3, 2)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(0, 3, 5)
+	g.AddEdge(1, 2, 1) // 0 -> 1 -> 2 與 0 -> 2 並列
+
+	dag, err := NewShortestPathDAG(g, 0)
+	if err != nil {
+		t.Fatalf("NewShortestPathDAG failed: %v", err)
+	}
+	if want := []int{0, 1}; !reflect.DeepEqual(dag.Predecessors[2], want) {
+		t.Errorf("Expected predecessors of 2 to be %v, got %v", want, dag.Predecessors[2])
+	}
+	if dag.PathCount(3) != 3 || dag.PathCount(2) != 2 || dag.PathCount(4) != 0 {
+		t.Errorf("Unexpected path counts: %v", dag.PathCounts())
+	}
+
+	paths, err := AllShortestPaths(g, 0, 3)
+	if err != nil {
+		t.Fatalf("AllShortestPaths failed: %v", err)
+	}
+	want := [][]int{{0, 1, 3}, {0, 2, 3}, {0, 1, 2, 3}}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Expected %v, got %v", want, paths)
+	}
+
+	if _, err := AllShortestPaths(g, 0, 4); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath, got %v", err)
+	}
+	if paths, _ := AllShortestPaths(g, 0, 0); !reflect.DeepEqual(paths, [][]int{{0}}) {
+		t.Errorf("Expected the trivial path, got %v", paths)
+	}
+}
+
+func TestShortestPathDAGGrid(t *testing.T) {
+	g, _ := buildGrid(5, 5, nil)
+	dag, err := NewShortestPathDAG(g, 0)
+	if err != nil {
+		t.Fatalf("NewShortestPathDAG failed: %v", err)
+	}
+	if count := dag.PathCount(24); count != 70 {
+		t.Errorf("Expected C(8,4) = 70 paths, got %v", count)
+	}
+
+	it := dag.AllPaths(24)
+	seen := map[string]bool{}
+	for it.HasNext() {
+		nodes, err := it.Next()
+		if err != nil {
+			t.Fatalf("Next failed: %v", err)
+		}
+		path, err := newPath(g, nodes)
+		if err != nil {
+			t.Fatalf("Iterator produced an invalid path %v: %v", nodes, err)
+		}
+		if path.Cost != 8 {
+			t.Errorf("Path %v has cost %v, want 8", nodes, path.Cost)
+		}
+		seen[pathKey(nodes)] = true
+	}
+	if len(seen) != 70 {
+		t.Errorf("Expected 70 distinct paths, got %d", len(seen))
+	}
+	if _, err := it.Next(); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath after exhaustion, got %v", err)
+	}
+}
+
+func TestShortestPathDAGZeroWeights(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	for i := 0; i <= 3; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(2, 1, 0) // 1 可能在此邊被鬆弛前就已確定
+	g.AddEdge(1, 3, 1)
+
+	dag, err := NewShortestPathDAG(g, 0)
+	if err != nil {
+		t.Fatalf("NewShortestPathDAG failed: %v", err)
+	}
+	if dag.PathCount(3) != 2 {
+		t.Errorf("Expected 2 paths to 3, got %v", dag.PathCount(3))
+	}
+
+	g.AddEdge(1, 2, 0) // 零權重環
+	if _, err := NewShortestPathDAG(g, 0); err == nil {
+		t.Errorf("Expected error for zero-weight cycle")
+	}
+}
+
+func TestShortestPathDAGZeroWeightCycleThroughSource(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	for i := 0; i <= 2; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 0)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(1, 0, 1) // 權重為正的回邊不影響結果
+	if dag, err := NewShortestPathDAG(g, 0); err != nil || dag.PathCount(2) != 1 {
+		t.Fatalf("Expected 1 path to 2, got %v", err)
+	}
+
+	g.AddEdge(1, 0, 0) // 經過起點的零權重環
+	if _, err := NewShortestPathDAG(g, 0); err == nil {
+		t.Errorf("Expected error for zero-weight cycle through the source")
+	}
+
+	// 零權重自環同樣使路徑數無限
+	loop := NewAdjacencyList(true, true)
+	loop.AddNode(0)
+	loop.AddNode(1)
+	loop.AddEdge(0, 1, 1)
+	loop.AddEdge(1, 1, 0)
+	if _, err := NewShortestPathDAG(loop, 0); err == nil {
+		t.Errorf("Expected error for zero-weight self-loop")
+	}
+}
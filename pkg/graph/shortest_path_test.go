@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"errors"
 	"math"
 	"testing"
 )
@@ -117,3 +118,73 @@ func TestDijkstraWithVisualization(t *testing.T) {
 		}
 	}
 }
+
+func TestDijkstraArbitraryNodeIDs(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	for _, node := range []int{100, 205, -7, 42} {
+		g.AddNode(node)
+	}
+	g.AddEdge(100, 205, 3)
+	g.AddEdge(205, -7, 4)
+	g.AddEdge(100, -7, 10)
+
+	distances, predecessors, err := Dijkstra(g, 100)
+	if err != nil {
+		t.Fatalf("Dijkstra failed: %v", err)
+	}
+	if len(distances) != 4 {
+		t.Errorf("Expected distances for 4 nodes, got %v", distances)
+	}
+	if distances[-7] != 7 || !math.IsInf(distances[42], 1) {
+		t.Errorf("Unexpected distances: %v", distances)
+	}
+	if _, ok := distances[0]; ok {
+		t.Errorf("Distances should not contain nodes outside the graph: %v", distances)
+	}
+
+	nodes, err := PathTo(predecessors, 100, -7)
+	if err != nil {
+		t.Fatalf("PathTo failed: %v", err)
+	}
+	if len(nodes) != 3 || nodes[0] != 100 || nodes[1] != 205 || nodes[2] != -7 {
+		t.Errorf("Unexpected path: %v", nodes)
+	}
+	if _, err := PathTo(predecessors, 100, 42); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath for unreachable node, got %v", err)
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	g := NewAdjacencyList(false, true)
+	for i := 1; i <= 5; i++ {
+		g.AddNode(i * 10)
+	}
+	g.AddEdge(10, 20, 2)
+	g.AddEdge(20, 30, 2)
+	g.AddEdge(10, 30, 5)
+	g.AddEdge(30, 40, 1)
+
+	path, err := ShortestPath(g, 10, 40)
+	if err != nil {
+		t.Fatalf("ShortestPath failed: %v", err)
+	}
+	expected := []int{10, 20, 30, 40}
+	if len(path.Nodes) != len(expected) || path.Cost != 5 || len(path.Edges) != 3 {
+		t.Fatalf("Unexpected path: %+v", path)
+	}
+	for i := range expected {
+		if path.Nodes[i] != expected[i] {
+			t.Errorf("Expected nodes %v, got %v", expected, path.Nodes)
+			break
+		}
+	}
+
+	if _, err := ShortestPath(g, 10, 50); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath, got %v", err)
+	}
+
+	path, err = ShortestPath(g, 20, 20)
+	if err != nil || len(path.Nodes) != 1 || path.Cost != 0 {
+		t.Errorf("Expected trivial path, got %+v, %v", path, err)
+	}
+}
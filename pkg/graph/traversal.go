@@ -33,6 +33,16 @@ import (
 // 返回訪問過的節點列表
 
 func BFS(g Graph, start int) ([]int, error) {
+	return BFSWithOptions(g, start, SearchOptions{})
+}
+
+// BFSWithOptions 與 BFS 相同，但可透過 SearchOptions 取消遍歷或限制訪問的節點數。
+// 遍歷被中止時，返回目前為止訪問過的節點，以及包裝了
+// ErrSearchCanceled 或 ErrBudgetExceeded 的錯誤。
+func BFSWithOptions(g Graph, start int, opts SearchOptions) ([]int, error) {
+	guard := opts.newGuard()
+	defer guard.release()
+
 	visited := make(map[int]bool)
 	queue := []int{start}
 	result := []int{}
@@ -44,6 +54,9 @@ func BFS(g Graph, start int) ([]int, error) {
 		if visited[node] { // 如果節點已經訪問過，則跳過
 			continue
 		}
+		if err := guard.expand(); err != nil {
+			return result, err // 返回部分結果
+		}
 		visited[node] = true          // 標記節點為已訪問
 		result = append(result, node) // 將節點添加到結果列表中
 
@@ -60,35 +73,257 @@ func BFS(g Graph, start int) ([]int, error) {
 	return result, nil // 返回遍歷結果
 }
 
-// DFS performs a depth-first traversal of the graph starting from the given node.
-func DFS(g Graph, start int) ([]int, error) {
-	visited := make(map[int]bool)
-	result := []int{}
+// BFSOptions 控制 BFSTree 的行為
+type BFSOptions struct {
+	MaxDepth int           // 最大搜索深度（跳數），小於等於 0 表示不限制
+	Search   SearchOptions // 取消與展開節點數限制
+}
 
-	var dfs func(node int) error
-	dfs = func(node int) error {
-		visited[node] = true
-		result = append(result, node)
+// BFSResult 保存廣度優先搜索的完整結果
+type BFSResult struct {
+	Order  []int       // 節點的訪問順序
+	Depth  map[int]int // 每個節點與最近起點的距離（跳數）
+	Parent map[int]int // BFS 樹中的父節點（起點不包含在內）
+	Levels [][]int     // 依深度分組的節點，Levels[d] 為距離為 d 的節點
+}
 
-		neighbors, err := g.GetNeighbors(node)
+// BFSTree performs a breadth-first search from one or more sources and records
+// the hop distance, parent tree and level of every reached node.
+//
+// Parameters:
+// - g: The graph to traverse (must implement the Graph interface).
+// - sources: The starting nodes; all of them are at depth 0.
+// - opts: Options such as the maximum depth.
+//
+// Returns:
+// - A BFSResult describing the traversal.
+// - An error if a source node does not exist. If the search is aborted through
+// opts.Search, the partial result is returned together with the error.
+//
+// Example:
+// result, _ := BFSTree(g, []int{1}, BFSOptions{MaxDepth: 2})
+// fmt.Println(result.Levels) // 1 跳與 2 跳內的好友
+func BFSTree(g Graph, sources []int, opts BFSOptions) (*BFSResult, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("BFSTree requires at least one source node")
+	}
+
+	guard := opts.Search.newGuard()
+	defer guard.release()
+
+	result := &BFSResult{
+		Order:  []int{},
+		Depth:  make(map[int]int),
+		Parent: make(map[int]int),
+		Levels: [][]int{},
+	}
+
+	frontier := []int{}
+	for _, source := range sources {
+		if _, err := g.GetNeighbors(source); err != nil {
+			return nil, err // 起點不存在
+		}
+		if _, seen := result.Depth[source]; seen {
+			continue
+		}
+		result.Depth[source] = 0
+		frontier = append(frontier, source)
+	}
+
+	for depth := 0; len(frontier) > 0; depth++ {
+		result.Levels = append(result.Levels, frontier)
+		result.Order = append(result.Order, frontier...)
+		if opts.MaxDepth > 0 && depth >= opts.MaxDepth {
+			break // 已達最大深度，不再展開
+		}
+
+		next := []int{}
+		for _, node := range frontier {
+			if err := guard.expand(); err != nil {
+				// 已發現的下一層節點也納入部分結果，使 Levels 與 Depth 保持一致
+				if len(next) > 0 {
+					result.Levels = append(result.Levels, next)
+					result.Order = append(result.Order, next...)
+				}
+				return result, err
+			}
+			neighbors, err := g.GetNeighbors(node)
+			if err != nil {
+				return nil, err
+			}
+			for _, edge := range neighbors {
+				if _, seen := result.Depth[edge.To]; seen {
+					continue
+				}
+				result.Depth[edge.To] = depth + 1
+				result.Parent[edge.To] = node
+				next = append(next, edge.To)
+			}
+		}
+		frontier = next
+	}
+	return result, nil
+}
+
+// PathTo 返回從最近的起點到 target 的最短（跳數最少）路徑，若 target 未被訪問則返回 false
+func (r *BFSResult) PathTo(target int) ([]int, bool) {
+	if _, reached := r.Depth[target]; !reached {
+		return nil, false
+	}
+	path := make([]int, r.Depth[target]+1)
+	for i, node := len(path)-1, target; i >= 0; i-- {
+		path[i] = node
+		node = r.Parent[node]
+	}
+	return path, true
+}
+
+// BidirectionalBFS finds a path with the fewest edges between source and target
+// by searching from both ends at once and always expanding the smaller frontier.
+// Directed graphs are searched backwards through their in-edges; graphs that do
+// not implement InNeighborGraph have their reverse adjacency built once up front.
+//
+// Parameters:
+// - g: The graph to search (must implement the Graph interface).
+// - source: The start node.
+// - target: The goal node.
+//
+// Returns:
+// - The path from source to target, including both ends.
+// - An error wrapping ErrNoPath if target is unreachable, or an error if either node does not exist.
+//
+// Example:
+// path, _ := BidirectionalBFS(g, 1, 42)
+// fmt.Println(len(path) - 1) // 最少跳數
+func BidirectionalBFS(g Graph, source, target int) ([]int, error) {
+	if _, err := g.GetNeighbors(source); err != nil {
+		return nil, err
+	}
+	if _, err := g.GetNeighbors(target); err != nil {
+		return nil, err
+	}
+	if source == target {
+		return []int{source}, nil
+	}
+	inNeighbors, err := inNeighborFunc(g)
+	if err != nil {
+		return nil, err
+	}
+
+	forward := newBidirectionalSide(source, g.GetNeighbors)
+	backward := newBidirectionalSide(target, inNeighbors)
+
+	for len(forward.frontier) > 0 && len(backward.frontier) > 0 {
+		// 總是展開較小的一側，以減少探索的節點數
+		side, other := forward, backward
+		if len(backward.frontier) < len(forward.frontier) {
+			side, other = backward, forward
+		}
+		from, to, met, err := side.expandLevel(other)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		if !met {
+			continue
+		}
+		if side == backward {
+			// 反向搜索中的邊 from -> to 對應原圖中的 to -> from
+			from, to = to, from
 		}
 
-		for _, neighbor := range neighbors {
-			if !visited[neighbor.To] {
-				if err := dfs(neighbor.To); err != nil {
-					return err
+		// 組合路徑：source ... from -> to ... target
+		path := []int{}
+		for node := from; ; node = forward.parent[node] {
+			path = append([]int{node}, path...)
+			if node == source {
+				break
+			}
+		}
+		for node := to; ; node = backward.parent[node] {
+			path = append(path, node)
+			if node == target {
+				break
+			}
+		}
+		return path, nil
+	}
+	return nil, fmt.Errorf("%w: from %d to %d", ErrNoPath, source, target)
+}
+
+// bidirectionalSide 是雙向 BFS 其中一側的搜索狀態
+type bidirectionalSide struct {
+	neighbors func(node int) ([]Edge, error) // 此側展開節點的方式（出邊或入邊）
+	depth     map[int]int                    // 與此側起點的距離
+	parent    map[int]int                    // 此側搜索樹中的父節點
+	frontier  []int                          // 目前這一層的節點
+}
+
+func newBidirectionalSide(root int, neighbors func(node int) ([]Edge, error)) *bidirectionalSide {
+	return &bidirectionalSide{
+		neighbors: neighbors,
+		depth:     map[int]int{root: 0},
+		parent:    make(map[int]int),
+		frontier:  []int{root},
+	}
+}
+
+// expandLevel 展開整個目前層，若與另一側相遇則返回總長度最短的相遇邊 from -> to
+func (s *bidirectionalSide) expandLevel(other *bidirectionalSide) (from, to int, met bool, err error) {
+	best := -1
+	next := []int{}
+	for _, node := range s.frontier {
+		neighbors, err := s.neighbors(node)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		for _, edge := range neighbors {
+			if d, reached := other.depth[edge.To]; reached {
+				if length := s.depth[node] + 1 + d; best < 0 || length < best {
+					best, from, to, met = length, node, edge.To, true
 				}
 			}
+			if _, seen := s.depth[edge.To]; seen {
+				continue
+			}
+			s.depth[edge.To] = s.depth[node] + 1
+			s.parent[edge.To] = node
+			next = append(next, edge.To)
 		}
-		return nil
 	}
+	s.frontier = next
+	return from, to, met, nil
+}
 
-	if err := dfs(start); err != nil {
+// DFS performs a depth-first traversal of the graph starting from the given node.
+// It is a thin wrapper around DFSVisit that only keeps the discovery order;
+// the traversal uses an explicit stack, so very deep graphs do not grow the goroutine stack.
+func DFS(g Graph, start int) ([]int, error) {
+	return DFSWithOptions(g, start, SearchOptions{})
+}
+
+// DFSWithOptions 與 DFS 相同，但可透過 SearchOptions 取消遍歷或限制訪問的節點數。
+// 遍歷被中止時，返回目前為止訪問過的節點，以及包裝了
+// ErrSearchCanceled 或 ErrBudgetExceeded 的錯誤。
+func DFSWithOptions(g Graph, start int, opts SearchOptions) ([]int, error) {
+	guard := opts.newGuard()
+	defer guard.release()
+
+	order := []int{}
+	var aborted error
+	_, err := DFSVisit(g, DFSVisitor{
+		DiscoverNode: func(node int, time int) error {
+			if err := guard.expand(); err != nil {
+				aborted = err
+				return ErrStopTraversal
+			}
+			order = append(order, node)
+			return nil
+		},
+	}, start)
+	if err != nil {
 		return nil, err
 	}
-	return result, nil
+	return order, aborted
 }
 
 // RandomWalk performs a random walk on the graph for a specified number of steps.
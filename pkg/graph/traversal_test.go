@@ -147,3 +147,235 @@ func TestRandomWalk(t *testing.T) {
 	// 輸出完整路徑以便調試
 	t.Logf("Complete walk path: %v", walk)
 }
+
+func TestDFSVisitEdgeClassification(t *testing.T) {
+	g := NewAdjacencyList(true, false)
+	for _, node := range []int{1, 2, 3, 4, 5} {
+		g.AddNode(node)
+	}
+	// 1->2->3->1 構成環，1->3 為前向邊，4->3 為橫跨邊
+	g.AddEdge(1, 2, 0)
+	g.AddEdge(2, 3, 0)
+	g.AddEdge(3, 1, 0)
+	g.AddEdge(1, 3, 0)
+	g.AddEdge(4, 3, 0)
+	g.AddEdge(4, 5, 0)
+
+	var tree, back, forward, cross [][2]int
+	record := func(list *[][2]int) func(int, Edge) error {
+		return func(from int, edge Edge) error {
+			*list = append(*list, [2]int{from, edge.To})
+			return nil
+		}
+	}
+	result, err := DFSVisit(g, DFSVisitor{
+		TreeEdge:    record(&tree),
+		BackEdge:    record(&back),
+		ForwardEdge: record(&forward),
+		CrossEdge:   record(&cross),
+	})
+	if err != nil {
+		t.Fatalf("DFSVisit failed: %v", err)
+	}
+
+	if len(tree) != 3 || len(back) != 1 || len(forward) != 1 || len(cross) != 1 {
+		t.Fatalf("Unexpected classification: tree=%v back=%v forward=%v cross=%v", tree, back, forward, cross)
+	}
+	if back[0] != [2]int{3, 1} || forward[0] != [2]int{1, 3} || cross[0] != [2]int{4, 3} {
+		t.Errorf("Unexpected classification: back=%v forward=%v cross=%v", back, forward, cross)
+	}
+
+	// 驗證時間戳記：子節點的區間必須包含在父節點的區間內
+	for child, parent := range result.Parent {
+		if !(result.Discovery[parent] < result.Discovery[child] && result.Finish[child] < result.Finish[parent]) {
+			t.Errorf("Node %d interval is not nested in parent %d", child, parent)
+		}
+	}
+}
+
+func TestDFSVisitStop(t *testing.T) {
+	g := NewAdjacencyList(true, false)
+	for _, node := range []int{1, 2, 3, 4} {
+		g.AddNode(node)
+	}
+	g.AddEdge(1, 2, 0)
+	g.AddEdge(2, 3, 0)
+	g.AddEdge(3, 4, 0)
+
+	result, err := DFSVisit(g, DFSVisitor{
+		DiscoverNode: func(node int, time int) error {
+			if node == 3 {
+				return ErrStopTraversal
+			}
+			return nil
+		},
+	}, 1)
+	if err != nil {
+		t.Fatalf("DFSVisit failed: %v", err)
+	}
+	if !result.Stopped || len(result.Order) != 3 {
+		t.Errorf("Expected traversal to stop after node 3, got %v", result.Order)
+	}
+}
+
+func TestIsDAGAndFindCycle(t *testing.T) {
+	g := NewAdjacencyList(true, false)
+	for _, node := range []int{1, 2, 3, 4} {
+		g.AddNode(node)
+	}
+	g.AddEdge(1, 2, 0)
+	g.AddEdge(2, 3, 0)
+	g.AddEdge(3, 4, 0)
+
+	if !IsDAG(g) {
+		t.Errorf("Expected graph to be a DAG")
+	}
+
+	g.AddEdge(4, 2, 0)
+	if IsDAG(g) {
+		t.Errorf("Expected graph with cycle not to be a DAG")
+	}
+	cycle, found := FindCycle(g)
+	if !found {
+		t.Fatalf("Expected a cycle to be found")
+	}
+	expected := []int{2, 3, 4, 2}
+	if len(cycle) != len(expected) {
+		t.Fatalf("Expected cycle %v, got %v", expected, cycle)
+	}
+	for i := range expected {
+		if cycle[i] != expected[i] {
+			t.Errorf("Expected cycle %v, got %v", expected, cycle)
+			break
+		}
+	}
+}
+
+func TestBFSTree(t *testing.T) {
+	g := NewAdjacencyList(false, false)
+	for _, node := range []int{1, 2, 3, 4, 5, 6} {
+		g.AddNode(node)
+	}
+	edges := []struct{ from, to int }{
+		{1, 2}, {1, 3}, {2, 4}, {3, 4}, {4, 5}, {5, 6},
+	}
+	for _, edge := range edges {
+		g.AddEdge(edge.from, edge.to, 0)
+	}
+
+	result, err := BFSTree(g, []int{1}, BFSOptions{})
+	if err != nil {
+		t.Fatalf("BFSTree failed: %v", err)
+	}
+	expectedDepth := map[int]int{1: 0, 2: 1, 3: 1, 4: 2, 5: 3, 6: 4}
+	for node, depth := range expectedDepth {
+		if result.Depth[node] != depth {
+			t.Errorf("Incorrect depth for node %d: got %d, want %d", node, result.Depth[node], depth)
+		}
+	}
+	if len(result.Levels) != 5 || len(result.Levels[1]) != 2 {
+		t.Errorf("Unexpected levels: %v", result.Levels)
+	}
+	path, ok := result.PathTo(6)
+	if !ok || len(path) != 5 || path[0] != 1 || path[4] != 6 {
+		t.Errorf("Unexpected path to node 6: %v", path)
+	}
+
+	// 測試深度限制
+	limited, err := BFSTree(g, []int{1}, BFSOptions{MaxDepth: 2})
+	if err != nil {
+		t.Fatalf("BFSTree failed: %v", err)
+	}
+	if len(limited.Order) != 4 {
+		t.Errorf("Expected 4 nodes within 2 hops, got %v", limited.Order)
+	}
+	if _, ok := limited.PathTo(5); ok {
+		t.Errorf("Node 5 should not be reached within 2 hops")
+	}
+
+	// 測試多起點
+	multi, err := BFSTree(g, []int{1, 6}, BFSOptions{})
+	if err != nil {
+		t.Fatalf("BFSTree failed: %v", err)
+	}
+	if multi.Depth[5] != 1 || multi.Depth[4] != 2 {
+		t.Errorf("Unexpected multi-source depths: %v", multi.Depth)
+	}
+}
+
+func TestDFSDeepChain(t *testing.T) {
+	// 長鏈圖：遞歸實作在此會產生極深的呼叫堆疊
+	const n = 200000
+	g := NewAdjacencyList(true, false)
+	for i := 0; i < n; i++ {
+		g.AddNode(i)
+	}
+	for i := 0; i < n-1; i++ {
+		g.AddEdge(i, i+1, 0)
+	}
+
+	result, err := DFS(g, 0)
+	if err != nil {
+		t.Fatalf("DFS failed: %v", err)
+	}
+	if len(result) != n || result[n-1] != n-1 {
+		t.Errorf("Expected %d nodes in order, got %d", n, len(result))
+	}
+	if !IsDAG(g) {
+		t.Errorf("Expected chain to be a DAG")
+	}
+
+	g.AddEdge(n-1, 0, 0)
+	cycle, found := FindCycle(g)
+	if !found || len(cycle) != n+1 {
+		t.Errorf("Expected cycle of length %d, got %d", n+1, len(cycle))
+	}
+}
+
+// plainGraph 只暴露 Graph 介面，用於測試不支援 InNeighborGraph 的實作
+type plainGraph struct {
+	Graph
+}
+
+func TestBidirectionalBFS(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for _, directed := range []bool{true, false} {
+		g := NewAdjacencyList(directed, false)
+		const n = 300
+		for i := 0; i < n; i++ {
+			g.AddNode(i)
+		}
+		for i := 0; i < n*2; i++ {
+			g.AddEdge(rng.Intn(n), rng.Intn(n), 0)
+		}
+
+		for trial := 0; trial < 50; trial++ {
+			source, target := rng.Intn(n), rng.Intn(n)
+			tree, err := BFSTree(g, []int{source}, BFSOptions{})
+			if err != nil {
+				t.Fatalf("BFSTree failed: %v", err)
+			}
+			for _, graph := range []Graph{g, plainGraph{g}} {
+				path, err := BidirectionalBFS(graph, source, target)
+				depth, reachable := tree.Depth[target]
+				if !reachable {
+					if err == nil {
+						t.Errorf("Expected no path from %d to %d, got %v", source, target, path)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("BidirectionalBFS failed: %v", err)
+				}
+				if len(path)-1 != depth || path[0] != source || path[len(path)-1] != target {
+					t.Fatalf("Unexpected path %v from %d to %d, want length %d", path, source, target, depth)
+				}
+				for i := 0; i < len(path)-1; i++ {
+					if !g.HasEdge(path[i], path[i+1]) {
+						t.Fatalf("Path %v uses missing edge %d -> %d", path, path[i], path[i+1])
+					}
+				}
+			}
+		}
+	}
+}
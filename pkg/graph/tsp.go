@@ -0,0 +1,624 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+	"sort"
+)
+
+// Tour 是經過每個節點恰好一次並回到起點的環遊（旅行推銷員問題的解）
+type Tour struct {
+	Nodes []int   // 首尾相同的閉合節點序列
+	Cost  float64 // 經過的邊權重總和，平行邊取權重最小者
+}
+
+const (
+	heldKarpMaxNodes   = 20   // HeldKarp 接受的最大節點數
+	exactMatchingLimit = 20   // 超過此數量的節點改用貪婪匹配
+	tspEpsilon         = 1e-9 // 局部搜索只接受至少改善此數值的移動
+)
+
+// HeldKarp 以 Held–Karp 動態規劃求出成本最小的環遊，時間複雜度 O(2^V * V^2)，
+// 記憶體 O(2^V * V)，因此最多支援 20 個節點。支援有向圖（非對稱成本）與負權重。
+// 兩節點之間沒有邊時不能直接移動；若需要經由其他節點中轉，請先以全點對最短路徑建立完全圖。
+// 環遊從 ID 最小的節點出發。
+//
+// Returns:
+// - The optimal tour.
+// - An error wrapping ErrNoPath if the graph has no Hamiltonian cycle.
+//
+// Example:
+// tour, err := HeldKarp(g)
+// fmt.Println(tour.Nodes, tour.Cost)
+func HeldKarp(g Graph) (*Tour, error) {
+	m, err := newTSPMatrix(g)
+	if err != nil {
+		return nil, err
+	}
+	n := len(m.nodes)
+	if n > heldKarpMaxNodes {
+		return nil, fmt.Errorf("Held-Karp supports at most %d nodes, graph has %d", heldKarpMaxNodes, n)
+	}
+	if n == 1 {
+		return m.tour([]int{0})
+	}
+
+	// cost[mask*k+j]：從節點 0 出發、經過 mask 中的節點並停在 j 的最小成本，
+	// 節點 i（i >= 1）對應 mask 的第 i-1 位
+	k := n - 1
+	full := 1<<k - 1
+	cost := make([]float64, (full+1)*k)
+	parent := make([]int8, (full+1)*k)
+	for i := range cost {
+		cost[i] = math.Inf(1)
+	}
+	for j := 0; j < k; j++ {
+		cost[(1<<j)*k+j] = m.dist[0][j+1]
+	}
+	for mask := 1; mask <= full; mask++ {
+		for j := 0; j < k; j++ {
+			c := cost[mask*k+j]
+			if mask&(1<<j) == 0 || math.IsInf(c, 1) {
+				continue
+			}
+			for l := 0; l < k; l++ {
+				if mask&(1<<l) != 0 {
+					continue
+				}
+				next := (mask|1<<l)*k + l
+				if candidate := c + m.dist[j+1][l+1]; candidate < cost[next] {
+					cost[next] = candidate
+					parent[next] = int8(j)
+				}
+			}
+		}
+	}
+
+	best, last := math.Inf(1), 0
+	for j := 0; j < k; j++ {
+		if candidate := cost[full*k+j] + m.dist[j+1][0]; candidate < best {
+			best, last = candidate, j
+		}
+	}
+	if math.IsInf(best, 1) {
+		return nil, fmt.Errorf("%w: graph has no Hamiltonian cycle", ErrNoPath)
+	}
+
+	// 沿 parent 反向重建環遊
+	order := make([]int, 0, n)
+	for mask, j := full, last; mask != 0; {
+		order = append(order, j+1)
+		prev := int(parent[mask*k+j])
+		mask &^= 1 << j
+		j = prev
+	}
+	order = append(order, 0)
+	slices.Reverse(order)
+	return m.tour(order)
+}
+
+// NearestNeighborTour 從 start 出發，每次移動到成本最小的未拜訪節點（成本相同時取 ID 較小者），
+// 最後回到 start。速度快但通常比最佳解差 20% 以上，適合作為 TwoOpt 或 OrOpt 的初始解。
+//
+// Returns:
+// - A tour starting and ending at start.
+// - An error wrapping ErrNoPath if the walk gets stuck or cannot return to start.
+func NearestNeighborTour(g Graph, start int) (*Tour, error) {
+	m, err := newTSPMatrix(g)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := m.index[start]
+	if !ok {
+		return nil, fmt.Errorf("node %d does not exist in the graph", start)
+	}
+
+	visited := make([]bool, len(m.nodes))
+	visited[s] = true
+	order := []int{s}
+	for current := s; len(order) < len(m.nodes); {
+		found := false
+		var next int
+		for j, d := range m.dist[current] {
+			if !visited[j] && !math.IsInf(d, 1) && (!found || d < m.dist[current][next]) {
+				next, found = j, true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: nearest neighbour tour is stuck at node %d", ErrNoPath, m.nodes[current])
+		}
+		visited[next] = true
+		order = append(order, next)
+		current = next
+	}
+	if last := order[len(order)-1]; math.IsInf(m.dist[last][s], 1) {
+		return nil, fmt.Errorf("%w: from %d back to %d", ErrNoPath, m.nodes[last], start)
+	}
+	return m.tour(order)
+}
+
+// GreedyEdgeTour 以貪婪邊算法建立環遊：依權重遞增加入邊，
+// 只要不使節點的度數超過 2（有向圖為出度與入度各 1）且不提早形成環，
+// 最後以一條邊連接路徑的兩端。結果通常比 NearestNeighborTour 好。環遊從 ID 最小的節點出發。
+//
+// Returns:
+// - The greedy tour.
+// - An error wrapping ErrNoPath if the chosen edges cannot be closed into a tour.
+func GreedyEdgeTour(g Graph) (*Tour, error) {
+	m, err := newTSPMatrix(g)
+	if err != nil {
+		return nil, err
+	}
+	n := len(m.nodes)
+
+	type candidate struct {
+		from, to int
+		weight   float64
+	}
+	var candidates []candidate
+	for i := range m.nodes {
+		for j := range m.nodes {
+			if i != j && (m.directed || i < j) && !math.IsInf(m.dist[i][j], 1) {
+				candidates = append(candidates, candidate{from: i, to: j, weight: m.dist[i][j]})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(a, b int) bool { return candidates[a].weight < candidates[b].weight })
+
+	// 以並查集避免在加入最後一條邊之前形成環
+	root := make([]int, n)
+	for i := range root {
+		root[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if root[i] != i {
+			root[i] = find(root[i])
+		}
+		return root[i]
+	}
+	out, in := make([]int, n), make([]int, n)
+	links := make([][]int, n) // 已選擇的邊，有向圖只記錄出邊
+	chosen := 0
+	for _, c := range candidates {
+		if chosen == n-1 {
+			break
+		}
+		if find(c.from) == find(c.to) {
+			continue
+		}
+		if m.directed && (out[c.from] > 0 || in[c.to] > 0) ||
+			!m.directed && (len(links[c.from]) >= 2 || len(links[c.to]) >= 2) {
+			continue
+		}
+		root[find(c.from)] = find(c.to)
+		out[c.from]++
+		in[c.to]++
+		links[c.from] = append(links[c.from], c.to)
+		if !m.directed {
+			links[c.to] = append(links[c.to], c.from)
+		}
+		chosen++
+	}
+	if chosen < n-1 {
+		return nil, fmt.Errorf("%w: greedy edge heuristic could not join all nodes into a tour", ErrNoPath)
+	}
+
+	// 從路徑的一端出發，依序走過已選擇的邊
+	first := 0
+	for i := range m.nodes {
+		if m.directed && in[i] == 0 || !m.directed && len(links[i]) <= 1 {
+			first = i
+			break
+		}
+	}
+	order := []int{first}
+	visited := make([]bool, n)
+	visited[first] = true
+	for current := first; len(order) < n; {
+		for _, next := range links[current] {
+			if !visited[next] {
+				visited[next] = true
+				order = append(order, next)
+				current = next
+				break
+			}
+		}
+	}
+	if last := order[len(order)-1]; math.IsInf(m.dist[last][first], 1) {
+		return nil, fmt.Errorf("%w: from %d back to %d", ErrNoPath, m.nodes[last], m.nodes[first])
+	}
+
+	// 旋轉為從 ID 最小的節點出發
+	shift := slices.Index(order, 0)
+	return m.tour(append(order[shift:], order[:shift]...))
+}
+
+// TwoOpt 以 2-opt 局部搜索改善環遊：反轉一段子路徑以交換兩條邊，直到沒有任何交換能降低成本。
+// 有向圖中反轉後子路徑的成本也會改變，同樣計入比較。環遊的起點保持不變。
+//
+// Returns:
+// - A tour that is no more expensive than tour; the input is not modified.
+// - An error if tour does not visit every node of g exactly once along existing edges.
+//
+// Example:
+// tour, _ := NearestNeighborTour(g, 0)
+// tour, _ = TwoOpt(g, tour)
+// tour, _ = OrOpt(g, tour)
+func TwoOpt(g Graph, tour *Tour) (*Tour, error) {
+	m, err := newTSPMatrix(g)
+	if err != nil {
+		return nil, err
+	}
+	order, err := m.order(tour)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(order)
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i+2 < n; i++ {
+			for j := i + 2; j < n; j++ {
+				if i == 0 && j == n-1 {
+					// 兩條邊相鄰，交換沒有意義
+					continue
+				}
+				// 移除 a -> b 與 c -> e，改為 a -> c 與 b -> e，並反轉 b..c
+				a, b, c, e := order[i], order[i+1], order[j], order[(j+1)%n]
+				before := m.dist[a][b] + m.dist[c][e]
+				after := m.dist[a][c] + m.dist[b][e]
+				if m.directed {
+					for l := i + 1; l < j; l++ {
+						before += m.dist[order[l]][order[l+1]]
+						after += m.dist[order[l+1]][order[l]]
+					}
+				}
+				if after < before-tspEpsilon {
+					slices.Reverse(order[i+1 : j+1])
+					improved = true
+				}
+			}
+		}
+	}
+	return m.tour(order)
+}
+
+// OrOpt 以 Or-opt 局部搜索改善環遊：將連續 1 到 3 個節點的片段移到環遊的其他位置
+// （無向圖中也可以反向插入），直到沒有任何移動能降低成本。通常在 TwoOpt 之後使用。
+// 環遊的起點保持不變。
+//
+// Returns:
+// - A tour that is no more expensive than tour; the input is not modified.
+// - An error if tour does not visit every node of g exactly once along existing edges.
+func OrOpt(g Graph, tour *Tour) (*Tour, error) {
+	m, err := newTSPMatrix(g)
+	if err != nil {
+		return nil, err
+	}
+	order, err := m.order(tour)
+	if err != nil {
+		return nil, err
+	}
+
+	for improved := true; improved; {
+		improved = false
+		for length := 1; length <= 3 && !improved; length++ {
+			// 片段不包含位置 0，因此起點保持不變
+			for i := 1; i+length <= len(order) && !improved; i++ {
+				if next, ok := m.orOptMove(order, i, length); ok {
+					order, improved = next, true
+				}
+			}
+		}
+	}
+	return m.tour(order)
+}
+
+// orOptMove 為 order[i:i+length] 尋找成本最低的插入位置，若能降低成本則返回移動後的順序
+func (m *tspMatrix) orOptMove(order []int, i, length int) ([]int, bool) {
+	n := len(order)
+	if n-length < 2 {
+		return nil, false
+	}
+	segment := order[i : i+length]
+	first, last := segment[0], segment[length-1]
+	prev, next := order[i-1], order[(i+length)%n]
+	rest := append(append([]int{}, order[:i]...), order[i+length:]...)
+
+	bestGain, bestAt, bestReversed := tspEpsilon, 0, false
+	for k, x := range rest {
+		if x == prev {
+			// 插回原位置
+			continue
+		}
+		y := rest[(k+1)%len(rest)]
+		before := m.dist[prev][first] + m.dist[last][next] + m.dist[x][y]
+		if gain := before - (m.dist[prev][next] + m.dist[x][first] + m.dist[last][y]); gain > bestGain {
+			bestGain, bestAt, bestReversed = gain, k, false
+		}
+		if !m.directed {
+			if gain := before - (m.dist[prev][next] + m.dist[x][last] + m.dist[first][y]); gain > bestGain {
+				bestGain, bestAt, bestReversed = gain, k, true
+			}
+		}
+	}
+	if bestGain == tspEpsilon {
+		return nil, false
+	}
+
+	moved := append([]int{}, segment...)
+	if bestReversed {
+		slices.Reverse(moved)
+	}
+	result := append(append(append([]int{}, rest[:bestAt+1]...), moved...), rest[bestAt+1:]...)
+	return result, true
+}
+
+// Christofides 以 Christofides 算法為度量圖（完全無向圖，權重非負且滿足三角不等式）建立環遊：
+// 最小生成樹加上奇數度節點的最小權重完美匹配形成歐拉迴路，再略過重複的節點。
+// 奇數度節點不超過 20 個時使用精確匹配，保證成本不超過最佳解的 1.5 倍；
+// 超過時改用貪婪匹配加上兩兩交換改善，不再保證此上限。環遊從 ID 最小的節點出發。
+// 需要自訂成本時，以 WithWeights 視圖傳入，最小生成樹與匹配都會使用視圖的權重。
+//
+// Returns:
+// - The Christofides tour.
+// - An error if the graph is directed, not complete, has negative weights
+// or violates the triangle inequality.
+func Christofides(g Graph) (*Tour, error) {
+	if g.IsDirected() {
+		return nil, fmt.Errorf("Christofides requires an undirected graph")
+	}
+	if err := checkNonNegativeWeights(g); err != nil {
+		return nil, err
+	}
+	m, err := newTSPMatrix(g)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.checkMetric(); err != nil {
+		return nil, err
+	}
+	n := len(m.nodes)
+
+	// 以 Prim 算法在距離矩陣上建立最小生成樹
+	multi := &multigraph{adj: make(map[int][]edgeRef)}
+	inTree := make([]bool, n)
+	best := slices.Clone(m.dist[0])
+	from := make([]int, n)
+	inTree[0] = true
+	degree := make([]int, n)
+	for added := 1; added < n; added++ {
+		found := false
+		var v int
+		for j := range m.nodes {
+			if !inTree[j] && (!found || best[j] < best[v]) {
+				v, found = j, true
+			}
+		}
+		inTree[v] = true
+		multi.addEdge(from[v], v, m.dist[from[v]][v])
+		degree[from[v]]++
+		degree[v]++
+		for j := range m.nodes {
+			if !inTree[j] && m.dist[v][j] < best[j] {
+				best[j], from[j] = m.dist[v][j], v
+			}
+		}
+	}
+
+	// 奇數度節點的最小權重完美匹配
+	var odd []int
+	for i, d := range degree {
+		if d%2 == 1 {
+			odd = append(odd, i)
+		}
+	}
+	for _, pair := range minWeightPerfectMatching(len(odd), func(a, b int) float64 { return m.dist[odd[a]][odd[b]] }) {
+		u, v := odd[pair[0]], odd[pair[1]]
+		multi.addEdge(u, v, m.dist[u][v])
+	}
+
+	// 歐拉迴路略過已拜訪的節點即為環遊
+	circuit, _ := multi.hierholzer(0)
+	visited := make([]bool, n)
+	order := make([]int, 0, n)
+	for _, i := range circuit {
+		if !visited[i] {
+			visited[i] = true
+			order = append(order, i)
+		}
+	}
+	return m.tour(order)
+}
+
+// minWeightPerfectMatching 返回 0..k-1（k 為偶數）之間權重總和最小的完美匹配。
+// k 不超過 exactMatchingLimit 時以位元遮罩動態規劃求精確解，時間 O(2^k * k)；
+// 否則使用貪婪匹配，再交換兩組配對直到無法改善。
+func minWeightPerfectMatching(k int, weight func(a, b int) float64) [][2]int {
+	if k > exactMatchingLimit {
+		return greedyMatching(k, weight)
+	}
+
+	// cost[mask]：完美匹配 mask 中節點的最小權重，mask 中編號最小的節點與 partner[mask] 配對
+	size := 1 << k
+	cost := make([]float64, size)
+	partner := make([]int8, size)
+	for mask := 1; mask < size; mask++ {
+		if bits.OnesCount(uint(mask))%2 == 1 {
+			continue
+		}
+		i := bits.TrailingZeros(uint(mask))
+		rest := mask &^ (1 << i)
+		found := false
+		for r := rest; r != 0; r &= r - 1 {
+			j := bits.TrailingZeros(uint(r))
+			if c := cost[rest&^(1<<j)] + weight(i, j); !found || c < cost[mask] {
+				cost[mask], partner[mask], found = c, int8(j), true
+			}
+		}
+	}
+
+	pairs := make([][2]int, 0, k/2)
+	for mask := size - 1; mask != 0; {
+		i := bits.TrailingZeros(uint(mask))
+		j := int(partner[mask])
+		pairs = append(pairs, [2]int{i, j})
+		mask &^= 1<<i | 1<<j
+	}
+	return pairs
+}
+
+// greedyMatching 依權重遞增配對節點，再以兩兩交換改善
+func greedyMatching(k int, weight func(a, b int) float64) [][2]int {
+	type candidate struct {
+		a, b   int
+		weight float64
+	}
+	candidates := make([]candidate, 0, k*(k-1)/2)
+	for a := 0; a < k; a++ {
+		for b := a + 1; b < k; b++ {
+			candidates = append(candidates, candidate{a: a, b: b, weight: weight(a, b)})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].weight < candidates[j].weight })
+
+	matched := make([]bool, k)
+	pairs := make([][2]int, 0, k/2)
+	for _, c := range candidates {
+		if !matched[c.a] && !matched[c.b] {
+			matched[c.a], matched[c.b] = true, true
+			pairs = append(pairs, [2]int{c.a, c.b})
+		}
+	}
+
+	for improved := true; improved; {
+		improved = false
+		for i := range pairs {
+			for j := i + 1; j < len(pairs); j++ {
+				a, b, c, d := pairs[i][0], pairs[i][1], pairs[j][0], pairs[j][1]
+				current := weight(a, b) + weight(c, d)
+				if weight(a, c)+weight(b, d) < current-tspEpsilon {
+					pairs[i], pairs[j] = [2]int{a, c}, [2]int{b, d}
+					improved = true
+				} else if weight(a, d)+weight(b, c) < current-tspEpsilon {
+					pairs[i], pairs[j] = [2]int{a, d}, [2]int{b, c}
+					improved = true
+				}
+			}
+		}
+	}
+	return pairs
+}
+
+// tspMatrix 是 TSP 算法使用的距離矩陣
+type tspMatrix struct {
+	directed bool
+	nodes    []int       // 依 ID 遞增排序的節點
+	index    map[int]int // 節點 ID 到矩陣索引的映射
+	dist     [][]float64 // dist[i][j] 為 nodes[i] 到 nodes[j] 的邊權重，沒有邊時為 math.Inf(1)
+}
+
+// newTSPMatrix 以直接相連的邊建立距離矩陣，平行邊取權重最小者
+func newTSPMatrix(g Graph) (*tspMatrix, error) {
+	if !g.IsWeighted() {
+		return nil, fmt.Errorf("TSP requires a weighted graph")
+	}
+	nodes := g.GetNodes()
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("TSP requires at least one node")
+	}
+	sort.Ints(nodes)
+
+	m := &tspMatrix{
+		directed: g.IsDirected(),
+		nodes:    nodes,
+		index:    make(map[int]int, len(nodes)),
+		dist:     make([][]float64, len(nodes)),
+	}
+	for i, node := range nodes {
+		m.index[node] = i
+		m.dist[i] = make([]float64, len(nodes))
+		for j := range nodes {
+			m.dist[i][j] = math.Inf(1)
+		}
+		m.dist[i][i] = 0
+	}
+	for i, u := range nodes {
+		neighbors, err := g.GetNeighbors(u)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range neighbors {
+			if j := m.index[edge.To]; j != i && edge.Weight < m.dist[i][j] {
+				m.dist[i][j] = edge.Weight
+			}
+		}
+	}
+	return m, nil
+}
+
+// tour 將索引順序轉換為閉合的 Tour
+func (m *tspMatrix) tour(order []int) (*Tour, error) {
+	t := &Tour{Nodes: make([]int, 0, len(order)+1)}
+	for k, i := range order {
+		t.Nodes = append(t.Nodes, m.nodes[i])
+		t.Cost += m.dist[i][order[(k+1)%len(order)]]
+	}
+	t.Nodes = append(t.Nodes, m.nodes[order[0]])
+	if math.IsInf(t.Cost, 1) {
+		return nil, fmt.Errorf("%w: tour uses a missing edge", ErrNoPath)
+	}
+	return t, nil
+}
+
+// order 驗證 tour 恰好拜訪每個節點一次並只經過存在的邊，返回不含終點的索引順序
+func (m *tspMatrix) order(tour *Tour) ([]int, error) {
+	n := len(m.nodes)
+	if tour == nil || len(tour.Nodes) != n+1 || tour.Nodes[0] != tour.Nodes[n] {
+		return nil, fmt.Errorf("tour must visit each of the %d nodes exactly once and return to its start", n)
+	}
+	order := make([]int, n)
+	seen := make([]bool, n)
+	for k, node := range tour.Nodes[:n] {
+		i, ok := m.index[node]
+		if !ok {
+			return nil, fmt.Errorf("node %d does not exist in the graph", node)
+		}
+		if seen[i] {
+			return nil, fmt.Errorf("tour visits node %d more than once", node)
+		}
+		seen[i] = true
+		order[k] = i
+	}
+	for k, i := range order {
+		if j := order[(k+1)%n]; math.IsInf(m.dist[i][j], 1) {
+			return nil, fmt.Errorf("edge %d -> %d does not exist", m.nodes[i], m.nodes[j])
+		}
+	}
+	return order, nil
+}
+
+// checkMetric 檢查距離矩陣是否為完全圖並滿足三角不等式
+func (m *tspMatrix) checkMetric() error {
+	for i, row := range m.dist {
+		for j, d := range row {
+			if math.IsInf(d, 1) {
+				return fmt.Errorf("metric TSP requires a complete graph: no edge between %d and %d", m.nodes[i], m.nodes[j])
+			}
+		}
+	}
+	for i := range m.nodes {
+		for j := range m.nodes {
+			for k := range m.nodes {
+				if m.dist[i][k] > m.dist[i][j]+m.dist[j][k]+tspEpsilon {
+					return fmt.Errorf("metric TSP requires the triangle inequality: %d -> %d is longer than %d -> %d -> %d",
+						m.nodes[i], m.nodes[k], m.nodes[i], m.nodes[j], m.nodes[k])
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// completeGraph 建立以 weight 為權重的完全圖
+func completeGraph(n int, directed bool, weight func(i, j int) float64) *AdjacencyList {
+	g := NewAdjacencyList(directed, true)
+	for i := 0; i < n; i++ {
+		g.AddNode(i)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j && (directed || i < j) {
+				g.AddEdge(i, j, weight(i, j))
+			}
+		}
+	}
+	return g
+}
+
+// euclideanGraph 建立平面上的點兩兩以歐氏距離相連的完全圖
+func euclideanGraph(points []Point) *AdjacencyList {
+	return completeGraph(len(points), false, func(i, j int) float64 {
+		return math.Hypot(points[i].X-points[j].X, points[i].Y-points[j].Y)
+	})
+}
+
+// bruteForceTour 列舉所有排列求最佳環遊成本
+func bruteForceTour(g *AdjacencyList) float64 {
+	m, _ := newTSPMatrix(g)
+	n := len(m.nodes)
+	best := math.Inf(1)
+	order := []int{0}
+	used := make([]bool, n)
+	used[0] = true
+	var search func(cost float64)
+	search = func(cost float64) {
+		last := order[len(order)-1]
+		if len(order) == n {
+			best = math.Min(best, cost+m.dist[last][0])
+			return
+		}
+		for next := 1; next < n; next++ {
+			if !used[next] {
+				used[next] = true
+				order = append(order, next)
+				search(cost + m.dist[last][next])
+				order = order[:len(order)-1]
+				used[next] = false
+			}
+		}
+	}
+	search(0)
+	return best
+}
+
+// checkTour 驗證 tour 恰好拜訪每個節點一次且成本正確
+func checkTour(t *testing.T, g *AdjacencyList, tour *Tour) {
+	t.Helper()
+	m, _ := newTSPMatrix(g)
+	order, err := m.order(tour)
+	if err != nil {
+		t.Fatalf("invalid tour %v: %v", tour.Nodes, err)
+	}
+	expected, _ := m.tour(order)
+	if math.Abs(expected.Cost-tour.Cost) > 1e-9 {
+		t.Errorf("tour %v reports cost %v, expected %v", tour.Nodes, tour.Cost, expected.Cost)
+	}
+}
+
+func TestHeldKarp(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for _, directed := range []bool{false, true} {
+		g := completeGraph(8, directed, func(i, j int) float64 { return float64(1 + rng.Intn(50)) })
+		tour, err := HeldKarp(g)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkTour(t, g, tour)
+		if tour.Nodes[0] != 0 {
+			t.Errorf("tour should start at node 0, got %v", tour.Nodes)
+		}
+		if best := bruteForceTour(g); tour.Cost != best {
+			t.Errorf("directed=%v: expected optimal cost %v, got %v", directed, best, tour.Cost)
+		}
+	}
+
+	// 星狀圖沒有 Hamiltonian 環
+	star := NewAdjacencyList(false, true)
+	for i := 0; i < 4; i++ {
+		star.AddNode(i)
+	}
+	for i := 1; i < 4; i++ {
+		star.AddEdge(0, i, 1)
+	}
+	if _, err := HeldKarp(star); !errors.Is(err, ErrNoPath) {
+		t.Errorf("expected ErrNoPath, got %v", err)
+	}
+
+	big := completeGraph(heldKarpMaxNodes+1, false, func(i, j int) float64 { return 1 })
+	if _, err := HeldKarp(big); err == nil {
+		t.Error("expected an error for too many nodes")
+	}
+
+	single := NewAdjacencyList(false, true)
+	single.AddNode(5)
+	tour, err := HeldKarp(single)
+	if err != nil || len(tour.Nodes) != 2 || tour.Cost != 0 {
+		t.Errorf("expected trivial tour, got %v, %v", tour, err)
+	}
+}
+
+func TestTourHeuristics(t *testing.T) {
+	// 凸多邊形上的點：2-opt 的局部最佳解沒有交叉，因此就是沿多邊形的最佳環遊
+	rng := rand.New(rand.NewSource(3))
+	points := make([]Point, 12)
+	for i, k := range rng.Perm(len(points)) {
+		angle := 2 * math.Pi * float64(k) / float64(len(points))
+		points[i] = Point{X: math.Cos(angle), Y: math.Sin(angle)}
+	}
+	g := euclideanGraph(points)
+	optimal, err := HeldKarp(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	nearest, err := NearestNeighborTour(g, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkTour(t, g, nearest)
+	if nearest.Nodes[0] != 3 {
+		t.Errorf("tour should start at node 3, got %v", nearest.Nodes)
+	}
+
+	greedy, err := GreedyEdgeTour(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkTour(t, g, greedy)
+
+	christofides, err := Christofides(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkTour(t, g, christofides)
+	if christofides.Cost > 1.5*optimal.Cost+1e-9 {
+		t.Errorf("Christofides cost %v exceeds 1.5 x optimum %v", christofides.Cost, optimal.Cost)
+	}
+
+	for _, start := range []*Tour{nearest, greedy, christofides} {
+		improved, err := TwoOpt(g, start)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkTour(t, g, improved)
+		if improved.Nodes[0] != start.Nodes[0] {
+			t.Errorf("2-opt moved the start from %d to %d", start.Nodes[0], improved.Nodes[0])
+		}
+		if math.Abs(improved.Cost-optimal.Cost) > 1e-9 {
+			t.Errorf("2-opt from %v: expected optimal cost %v, got %v", start.Nodes, optimal.Cost, improved.Cost)
+		}
+	}
+
+	// Or-opt 不應使成本變差，並能把錯位的節點移回原位
+	bad := &Tour{Nodes: append([]int{}, optimal.Nodes...)}
+	bad.Nodes[2], bad.Nodes[6] = bad.Nodes[6], bad.Nodes[2]
+	moved, err := OrOpt(g, bad)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkTour(t, g, moved)
+	m, _ := newTSPMatrix(g)
+	order, _ := m.order(bad)
+	badTour, _ := m.tour(order)
+	if moved.Cost >= badTour.Cost {
+		t.Errorf("Or-opt did not improve cost %v", badTour.Cost)
+	}
+}
+
+func TestTourHeuristicsDirected(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	g := completeGraph(9, true, func(i, j int) float64 { return float64(1 + rng.Intn(30)) })
+	optimal, err := HeldKarp(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for name, build := range map[string]func() (*Tour, error){
+		"nearest": func() (*Tour, error) { return NearestNeighborTour(g, 0) },
+		"greedy":  func() (*Tour, error) { return GreedyEdgeTour(g) },
+	} {
+		tour, err := build()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		checkTour(t, g, tour)
+		improved, err := TwoOpt(g, tour)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		improved, err = OrOpt(g, improved)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		checkTour(t, g, improved)
+		if improved.Cost > tour.Cost || improved.Cost < optimal.Cost {
+			t.Errorf("%s: cost %v should be between optimum %v and %v", name, improved.Cost, optimal.Cost, tour.Cost)
+		}
+	}
+
+	if _, err := Christofides(g); err == nil {
+		t.Error("expected Christofides to reject a directed graph")
+	}
+}
+
+func TestTourErrors(t *testing.T) {
+	// 違反三角不等式：0-2 比 0-1-2 長
+	g := completeGraph(3, false, func(i, j int) float64 {
+		if i == 0 && j == 2 {
+			return 5
+		}
+		return 1
+	})
+	if _, err := Christofides(g); err == nil {
+		t.Error("expected a triangle inequality error")
+	}
+
+	// 路徑圖 0-1-2-3 沒有環遊
+	path := NewAdjacencyList(false, true)
+	for i := 0; i < 4; i++ {
+		path.AddNode(i)
+	}
+	for i := 0; i < 3; i++ {
+		path.AddEdge(i, i+1, 1)
+	}
+	if _, err := NearestNeighborTour(path, 0); !errors.Is(err, ErrNoPath) {
+		t.Errorf("expected ErrNoPath, got %v", err)
+	}
+	if _, err := GreedyEdgeTour(path); !errors.Is(err, ErrNoPath) {
+		t.Errorf("expected ErrNoPath, got %v", err)
+	}
+	if _, err := NearestNeighborTour(path, 9); err == nil {
+		t.Error("expected an error for a missing start node")
+	}
+
+	square := completeGraph(4, false, func(i, j int) float64 { return 1 })
+	if _, err := TwoOpt(square, &Tour{Nodes: []int{0, 1, 1, 2, 0}}); err == nil {
+		t.Error("expected an error for a tour visiting a node twice")
+	}
+	if _, err := OrOpt(square, &Tour{Nodes: []int{0, 1, 2, 3}}); err == nil {
+		t.Error("expected an error for an open tour")
+	}
+}
+
+func TestMinWeightPerfectMatching(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	points := make([]Point, 10)
+	for i := range points {
+		points[i] = Point{X: rng.Float64(), Y: rng.Float64()}
+	}
+	weight := func(a, b int) float64 {
+		return math.Hypot(points[a].X-points[b].X, points[a].Y-points[b].Y)
+	}
+	total := func(pairs [][2]int) float64 {
+		sum := 0.0
+		seen := make(map[int]bool)
+		for _, p := range pairs {
+			if seen[p[0]] || seen[p[1]] || p[0] == p[1] {
+				t.Fatalf("invalid matching %v", pairs)
+			}
+			seen[p[0]], seen[p[1]] = true, true
+			sum += weight(p[0], p[1])
+		}
+		if len(seen) != len(points) {
+			t.Fatalf("matching %v is not perfect", pairs)
+		}
+		return sum
+	}
+
+	// 以遞迴列舉所有完美匹配求最佳值
+	var best func(free []int) float64
+	best = func(free []int) float64 {
+		if len(free) == 0 {
+			return 0
+		}
+		result := math.Inf(1)
+		for k := 1; k < len(free); k++ {
+			rest := append(append([]int{}, free[1:k]...), free[k+1:]...)
+			result = math.Min(result, weight(free[0], free[k])+best(rest))
+		}
+		return result
+	}
+	expected := best([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+
+	if got := total(minWeightPerfectMatching(len(points), weight)); math.Abs(got-expected) > 1e-9 {
+		t.Errorf("expected exact matching weight %v, got %v", expected, got)
+	}
+	if got := total(greedyMatching(len(points), weight)); got < expected-1e-9 {
+		t.Errorf("greedy matching weight %v is below the optimum %v", got, expected)
+	}
+}
@@ -1,5 +1,7 @@
 package graph
 
+import "fmt"
+
 // IsDAG checks whether the given graph is a Directed Acyclic Graph (DAG).
 //
 // Parameters:
@@ -17,31 +19,88 @@ package graph
 // isDAG := IsDAG(g)
 // fmt.Println(isDAG) // Output: true
 func IsDAG(g Graph) bool {
-	visited := make(map[int]bool)
-	recursionStack := make(map[int]bool)
+	if !g.IsDirected() {
+		// 無向圖中的每條邊都可雙向通行，只有沒有邊時才不存在環
+		return g.EdgeCount() == 0
+	}
+	_, found := FindCycle(g)
+	return !found
+}
 
-	var dfs func(node int) bool
-	dfs = func(node int) bool {
-		visited[node] = true
-		recursionStack[node] = true
+// FindCycle searches the graph for a cycle using DFSVisit.
+//
+// Parameters:
+// - g: The graph to search (must implement the Graph interface).
+//
+// Returns:
+// - The cycle as a closed node list whose first and last elements are the same node.
+// - true if a cycle was found, false otherwise.
+//
+// Example:
+// g := NewAdjacencyList(true, false)
+// g.AddNode(1)
+// g.AddNode(2)
+// g.AddEdge(1, 2, 0)
+// g.AddEdge(2, 1, 0)
+// cycle, found := FindCycle(g)
+// fmt.Println(cycle, found) // Output: [1 2 1] true
+func FindCycle(g Graph) ([]int, bool) {
+	var cycle []int
+	parent := make(map[int]int)
 
-		neighbors, _ := g.GetNeighbors(node)
-		for _, edge := range neighbors {
-			if !visited[edge.To] && dfs(edge.To) {
-				return true
-			} else if recursionStack[edge.To] {
-				return true
+	_, err := DFSVisit(g, DFSVisitor{
+		TreeEdge: func(from int, edge Edge) error {
+			parent[edge.To] = from
+			return nil
+		},
+		BackEdge: func(from int, edge Edge) error {
+			// 回邊 from -> edge.To 加上樹中 edge.To 到 from 的路徑即構成環
+			path := []int{from}
+			for node := from; node != edge.To; {
+				node = parent[node]
+				path = append(path, node)
 			}
-		}
+			cycle = make([]int, 0, len(path)+1)
+			for i := len(path) - 1; i >= 0; i-- {
+				cycle = append(cycle, path[i])
+			}
+			cycle = append(cycle, edge.To)
+			return ErrStopTraversal
+		},
+	})
+	if err != nil || cycle == nil {
+		return nil, false
+	}
+	return cycle, true
+}
 
-		recursionStack[node] = false
-		return false
+// inNeighborFunc 返回查詢入邊的函數。
+// 若圖實作了 InNeighborGraph 則直接使用；無向圖的入邊即為鄰居；
+// 否則掃描整張圖一次建立反向鄰接表。
+func inNeighborFunc(g Graph) (func(node int) ([]Edge, error), error) {
+	if ig, ok := g.(InNeighborGraph); ok {
+		return ig.GetInNeighbors, nil
+	}
+	if !g.IsDirected() {
+		return g.GetNeighbors, nil
 	}
 
-	for node := range visited {
-		if !visited[node] && dfs(node) {
-			return false
+	reverse := make(map[int][]Edge)
+	exists := make(map[int]bool)
+	for _, from := range g.GetNodes() {
+		exists[from] = true
+		neighbors, err := g.GetNeighbors(from)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range neighbors {
+			reverse[edge.To] = append(reverse[edge.To], Edge{To: from, Weight: edge.Weight})
 		}
 	}
-	return true
+	return func(node int) ([]Edge, error) {
+		if !exists[node] {
+			return nil, fmt.Errorf("node %d does not exist in the graph", node)
+		}
+		return reverse[node], nil
+	}, nil
 }
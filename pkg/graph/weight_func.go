@@ -0,0 +1,89 @@
+package graph
+
+import "math"
+
+// WeightFunc 在搜索時動態計算經過邊 from -> to 的成本，取代固定的 Edge.Weight。
+// 可以依外部屬性計算成本，例如依時間變化的行駛時間、懲罰項，或將相似度轉換為距離，
+// 而不需要為每一種成本模型建立新的圖。返回 math.Inf(1) 表示該邊不可通行。
+// 搭配 A* 使用時，啟發式函數必須以自訂成本為準仍然可採納。
+//
+// Example:
+// // 相似度越高距離越短
+// distance := func(from, to int, e Edge) float64 { return 1 - e.Weight }
+// path, _ := ShortestPathWithOptions(g, 1, 9, SearchOptions{Weight: distance})
+type WeightFunc func(from, to int, edge Edge) float64
+
+// WithWeights 返回以 weight 計算邊權重的圖視圖，不複製原圖；原圖的修改會立即反映在視圖上。
+// 視圖總是加權圖，因此也可以為無權圖指定成本。成本為 math.Inf(1) 的邊會被隱藏。
+// 若原圖實作了 InNeighborGraph，視圖也會實作，入邊同樣以 weight 計算。
+//
+// 任何接受 Graph 的演算法都可以透過視圖使用自訂成本。沒有 SearchOptions 的算法，
+// 例如 Christofides（內部的最小生成樹）與 ChinesePostman（最小成本流），以視圖作為指定 WeightFunc 的方式。
+// weight 為 nil 時直接返回 g。
+func WithWeights(g Graph, weight WeightFunc) Graph {
+	if weight == nil {
+		return g
+	}
+	view := weightedView{Graph: g, weight: weight}
+	if ig, ok := g.(InNeighborGraph); ok {
+		return &weightedInView{weightedView: view, in: ig}
+	}
+	return &view
+}
+
+// weightedView 以 WeightFunc 重新計算邊權重的圖
+type weightedView struct {
+	Graph
+	weight WeightFunc
+}
+
+func (w *weightedView) IsWeighted() bool {
+	return true
+}
+
+func (w *weightedView) GetNeighbors(node int) ([]Edge, error) {
+	neighbors, err := w.Graph.GetNeighbors(node)
+	if err != nil {
+		return nil, err
+	}
+	edges := make([]Edge, 0, len(neighbors))
+	for _, edge := range neighbors {
+		if cost := w.weight(node, edge.To, edge); !math.IsInf(cost, 1) {
+			edges = append(edges, Edge{To: edge.To, Weight: cost})
+		}
+	}
+	return edges, nil
+}
+
+func (w *weightedView) GetEdges(node int) ([]Edge, error) {
+	return w.GetNeighbors(node)
+}
+
+// weightedInView 是支援入邊查詢的 weightedView
+type weightedInView struct {
+	weightedView
+	in InNeighborGraph
+}
+
+func (w *weightedInView) GetInNeighbors(node int) ([]Edge, error) {
+	in, err := w.in.GetInNeighbors(node)
+	if err != nil {
+		return nil, err
+	}
+	edges := make([]Edge, 0, len(in))
+	for _, edge := range in {
+		// 入邊的 Edge.To 為邊的起點，傳給 WeightFunc 時還原為 from -> node 的邊
+		if cost := w.weight(edge.To, node, Edge{To: node, Weight: edge.Weight}); !math.IsInf(cost, 1) {
+			edges = append(edges, Edge{To: edge.To, Weight: cost})
+		}
+	}
+	return edges, nil
+}
+
+// graph 返回套用 Weight 後的圖；未設定 Weight 時返回原圖
+func (o SearchOptions) graph(g Graph) Graph {
+	if o.Weight == nil {
+		return g
+	}
+	return WithWeights(g, o.Weight)
+}
@@ -0,0 +1,164 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestWeightFuncDijkstra(t *testing.T) {
+	// 無權圖搭配固定成本，等同於計算跳數
+	g := NewAdjacencyList(true, false)
+	for i := 0; i < 5; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 0)
+	g.AddEdge(1, 2, 0)
+	g.AddEdge(0, 3, 0)
+	g.AddEdge(3, 2, 0)
+	g.AddEdge(2, 4, 0)
+
+	hops := func(from, to int, e Edge) float64 { return 1 }
+	distances, _, err := DijkstraWithOptions(g, 0, SearchOptions{Weight: hops})
+	if err != nil {
+		t.Fatalf("DijkstraWithOptions failed: %v", err)
+	}
+	want := map[int]float64{0: 0, 1: 1, 2: 2, 3: 1, 4: 3}
+	if !reflect.DeepEqual(distances, want) {
+		t.Errorf("Expected %v, got %v", want, distances)
+	}
+	if _, _, err := Dijkstra(g, 0); err == nil {
+		t.Errorf("Expected Dijkstra without a weight function to reject an unweighted graph")
+	}
+}
+
+func TestWeightFuncPenalties(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	for i := 0; i < 4; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 3, 1)
+	g.AddEdge(0, 2, 2)
+	g.AddEdge(2, 3, 2)
+
+	// 封閉 1 -> 3，並將所有成本加倍
+	closed := func(from, to int, e Edge) float64 {
+		if from == 1 && to == 3 {
+			return math.Inf(1)
+		}
+		return 2 * e.Weight
+	}
+	path, err := ShortestPathWithOptions(g, 0, 3, SearchOptions{Weight: closed})
+	if err != nil {
+		t.Fatalf("ShortestPathWithOptions failed: %v", err)
+	}
+	if !reflect.DeepEqual(path.Nodes, []int{0, 2, 3}) || path.Cost != 8 {
+		t.Errorf("Expected [0 2 3] with cost 8, got %v with cost %v", path.Nodes, path.Cost)
+	}
+
+	result, err := AStarSearch(g, 0, 3, func(node, goal int) float64 { return 0 }, AStarOptions{Search: SearchOptions{Weight: closed}})
+	if err != nil {
+		t.Fatalf("AStarSearch failed: %v", err)
+	}
+	if result.Path.Cost != 8 {
+		t.Errorf("Expected A* cost 8, got %v", result.Path.Cost)
+	}
+
+	negative := func(from, to int, e Edge) float64 { return -e.Weight }
+	if _, err := ShortestPathWithOptions(g, 0, 3, SearchOptions{Weight: negative}); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Expected ErrNegativeWeight, got %v", err)
+	}
+}
+
+func TestWithWeights(t *testing.T) {
+	g := NewAdjacencyList(true, true)
+	for i := 0; i < 3; i++ {
+		g.AddNode(i)
+	}
+	g.AddEdge(0, 1, 4)
+	g.AddEdge(1, 2, 4)
+
+	half := WithWeights(g, func(from, to int, e Edge) float64 { return e.Weight / 2 })
+	in, ok := half.(InNeighborGraph)
+	if !ok {
+		t.Fatalf("Expected the view to keep GetInNeighbors")
+	}
+	if edges, _ := in.GetInNeighbors(2); !reflect.DeepEqual(edges, []Edge{{To: 1, Weight: 2}}) {
+		t.Errorf("Unexpected in-neighbors: %v", edges)
+	}
+	path, err := BidirectionalDijkstra(half, 0, 2)
+	if err != nil || path.Cost != 4 {
+		t.Errorf("Expected cost 4 through the view, got %v (%v)", path, err)
+	}
+
+	// 視圖反映原圖的修改
+	g.UpdateEdgeWeight(1, 2, 10)
+	if path, _ := ShortestPath(half, 0, 2); path.Cost != 7 {
+		t.Errorf("Expected cost 7 after update, got %v", path.Cost)
+	}
+	double := func(from, to int, e Edge) float64 { return 2 * e.Weight }
+	if _, ok := WithWeights(plainGraph{g}, double).(InNeighborGraph); ok {
+		t.Errorf("View of a graph without GetInNeighbors should not implement it")
+	}
+	if WithWeights(g, nil) != Graph(g) {
+		t.Errorf("Expected WithWeights with a nil function to return the graph unchanged")
+	}
+}
+
+func TestWithWeightsTourAndRoute(t *testing.T) {
+	// 四個點的正方形完全圖，邊長 1，對角線 1.5
+	g := completeGraph(4, false, func(i, j int) float64 {
+		if (j-i)%2 == 0 {
+			return 1.5
+		}
+		return 1
+	})
+	tour, err := Christofides(g)
+	if err != nil || tour.Cost != 4 {
+		t.Fatalf("Expected the perimeter tour of cost 4, got %v (%v)", tour, err)
+	}
+
+	// 透過視圖讓對角線變便宜：仍滿足三角不等式，最佳環遊改為沿對角線交叉
+	cheapDiagonals := func(from, to int, e Edge) float64 {
+		if e.Weight > 1 {
+			return 0.75
+		}
+		return e.Weight
+	}
+	tour, err = Christofides(WithWeights(g, cheapDiagonals))
+	if err != nil {
+		t.Fatalf("Christofides through the view failed: %v", err)
+	}
+	if optimal, _ := HeldKarp(WithWeights(g, cheapDiagonals)); tour.Cost > 1.5*optimal.Cost || optimal.Cost != 3.5 {
+		t.Errorf("Expected a tour within 1.5 x %v, got %v", optimal.Cost, tour.Cost)
+	}
+
+	// 有向圖的最小成本流同樣使用視圖的權重；原圖已平衡，路線成本為邊權重總和
+	d := NewAdjacencyList(true, true)
+	for i := 0; i < 4; i++ {
+		d.AddNode(i)
+	}
+	d.AddEdge(0, 1, 1)
+	d.AddEdge(1, 2, 1)
+	d.AddEdge(0, 2, 1)
+	d.AddEdge(2, 0, 5)
+	d.AddEdge(2, 3, 1)
+	d.AddEdge(3, 0, 1)
+	route, err := ChinesePostman(d)
+	if err != nil || route.Cost != 10 {
+		t.Fatalf("Expected cost 10, got %v (%v)", route, err)
+	}
+	// 將 2 -> 0 設為不可通行後，節點 2 多出一條入邊，需以流量補上 2 -> 3 -> 0
+	closed := func(from, to int, e Edge) float64 {
+		if from == 2 && to == 0 {
+			return math.Inf(1)
+		}
+		return e.Weight
+	}
+	route, err = ChinesePostman(WithWeights(d, closed))
+	if err != nil || route.Cost != 7 {
+		t.Errorf("Expected cost 7 through the view, got %v (%v)", route, err)
+	}
+}